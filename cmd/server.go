@@ -12,12 +12,18 @@ import (
 	"log/slog"
 	"net"
 	"os"
+	"record-orchestrator/pkg/events"
 	"record-orchestrator/pkg/memory"
+	"record-orchestrator/pkg/mixer"
 	pando "record-orchestrator/pkg/pandora"
 	roll20_sync "record-orchestrator/pkg/roll20-sync"
+	"record-orchestrator/pkg/webhooks"
 	pb "record-orchestrator/proto"
 	"record-orchestrator/services"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
 const (
@@ -28,37 +34,197 @@ const (
 	DEFAULT_PUBSUB_ID      = "pubsub"
 	DEFAULT_R20_ID         = "roll20-audio-sync"
 	DEFAULT_STATE_STORE_ID = "statestore"
+	// Namespaces of the sessions and their history in the state store
+	SESSIONS_NAMESPACE = "recorder-sessions"
+	HISTORY_NAMESPACE  = "recorder-history"
+	// Namespace of the scheduled recordings in the state store
+	SCHEDULES_NAMESPACE = "recorder-schedules"
+	// Namespace of the webhooks delivery log in the state store
+	WEBHOOKS_NAMESPACE = "recorder-webhooks"
+	// Namespace of the replies kept for idempotency keys in the state store
+	REPLIES_NAMESPACE = "recorder-replies"
+	// Sessions are stopped automatically once they lasted this long
+	DEFAULT_MAX_DURATION = 6 * time.Hour
+	// Delay before retrying a failed reconciliation, when sessions are only reconciled at startup
+	RECONCILE_RETRY = time.Minute
+	// Dapr subscribes to the topics of the app shortly after listing them
+	SUBSCRIBE_DELAY = 2 * time.Second
+	// Sessions are reconciled anyway if Dapr didn't list the subscriptions by then
+	SUBSCRIBE_TIMEOUT = time.Minute
+	// Delay between two prunings of the webhooks delivery log, and of the recordings history
+	PRUNE_INTERVAL = time.Hour
+	// Delay between two cleanups of the expired idempotency replies
+	FORGET_REPLIES_INTERVAL = time.Hour
+	// Method called by Dapr to list the subscriptions of the app
+	LIST_SUBSCRIPTIONS_METHOD = "/dapr.proto.runtime.v1.AppCallback/ListTopicSubscriptions"
 )
 
 type server struct {
 	pb.UnimplementedRecordServiceServer
-	service *services.Recorder
+	service   *services.Recorder
+	scheduler *services.Scheduler
 }
 
 func (s *server) Start(ctx context.Context, req *pb.StartRecordRequest) (*pb.StartRecordReply, error) {
 	if req.VoiceChannelId == "" {
-		return nil, fmt.Errorf("voice channel id is required")
+		return nil, services.ToStatus(fmt.Errorf("%w, voice channel id is required", services.ErrInvalidArgument))
 	}
 
 	slog.Info(fmt.Sprintf("[Server] :: Starting a new record with params %+v", req))
-	reply, err := s.service.Start(req)
+	reply, err := s.service.Start(ctx, req)
 	if err != nil {
 		slog.Error(fmt.Sprintf("[Server] :: Error starting a new record with params %+v, %s", req, err.Error()))
 	}
-	return reply, err
+	return reply, services.ToStatus(err)
 }
 
 func (s *server) Stop(ctx context.Context, req *pb.StopRecordRequest) (*pb.StopRecordReply, error) {
-	if req.VoiceChannelId == "" {
-		return nil, fmt.Errorf("voice channel id is required")
+	if req.VoiceChannelId == "" && req.SessionId == "" {
+		return nil, services.ToStatus(fmt.Errorf("%w, voice channel id or session id is required", services.ErrInvalidArgument))
 	}
 
 	slog.Info(fmt.Sprintf("[Server] :: Stopping record with params %+v", req))
-	reply, err := s.service.Stop(req)
+	reply, err := s.service.Stop(ctx, req)
 	if err != nil {
 		slog.Error(fmt.Sprintf("[Server] ::  Stopping record with params %+v, %s", req, err.Error()))
 	}
-	return reply, err
+	return reply, services.ToStatus(err)
+}
+
+func (s *server) Pause(ctx context.Context, req *pb.PauseRecordRequest) (*pb.PauseRecordReply, error) {
+	if req.VoiceChannelId == "" && req.SessionId == "" {
+		return nil, services.ToStatus(fmt.Errorf("%w, voice channel id or session id is required", services.ErrInvalidArgument))
+	}
+
+	slog.Info(fmt.Sprintf("[Server] :: Pausing record with params %+v", req))
+	reply, err := s.service.Pause(ctx, req)
+	if err != nil {
+		slog.Error(fmt.Sprintf("[Server] :: Error pausing record with params %+v, %s", req, err.Error()))
+	}
+	return reply, services.ToStatus(err)
+}
+
+func (s *server) Resume(ctx context.Context, req *pb.ResumeRecordRequest) (*pb.ResumeRecordReply, error) {
+	if req.VoiceChannelId == "" && req.SessionId == "" {
+		return nil, services.ToStatus(fmt.Errorf("%w, voice channel id or session id is required", services.ErrInvalidArgument))
+	}
+
+	slog.Info(fmt.Sprintf("[Server] :: Resuming record with params %+v", req))
+	reply, err := s.service.Resume(ctx, req)
+	if err != nil {
+		slog.Error(fmt.Sprintf("[Server] :: Error resuming record with params %+v, %s", req, err.Error()))
+	}
+	return reply, services.ToStatus(err)
+}
+
+func (s *server) GetRecording(ctx context.Context, req *pb.GetRecordingRequest) (*pb.GetRecordingReply, error) {
+	if req.VoiceChannelId == "" && req.SessionId == "" {
+		return nil, services.ToStatus(fmt.Errorf("%w, voice channel id or session id is required", services.ErrInvalidArgument))
+	}
+
+	reply, err := s.service.GetRecording(ctx, req)
+	if err != nil {
+		slog.Error(fmt.Sprintf("[Server] :: Error getting record with params %+v, %s", req, err.Error()))
+	}
+	return reply, services.ToStatus(err)
+}
+
+func (s *server) ListRecordings(ctx context.Context, req *pb.ListRecordingsRequest) (*pb.ListRecordingsReply, error) {
+	reply, err := s.service.ListRecordings(ctx, req)
+	if err != nil {
+		slog.Error(fmt.Sprintf("[Server] :: Error listing records with params %+v, %s", req, err.Error()))
+	}
+	return reply, services.ToStatus(err)
+}
+
+func (s *server) WatchRecording(req *pb.WatchRecordingRequest, stream pb.RecordService_WatchRecordingServer) error {
+	slog.Info(fmt.Sprintf("[Server] :: Watching records with params %+v", req))
+	evts, cancel := s.service.Watch(req.VoiceChannelId)
+	defer cancel()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case e := <-evts:
+			var metadata *pb.RecordingMetadata
+			if e.Metadata != nil {
+				metadata = &pb.RecordingMetadata{
+					Title:         e.Metadata.Title,
+					Campaign:      e.Metadata.Campaign,
+					SessionNumber: e.Metadata.SessionNumber,
+					Labels:        e.Metadata.Labels,
+				}
+			}
+			err := stream.Send(&pb.RecordingEvent{
+				Kind:           string(e.Kind),
+				SessionId:      e.SessionId,
+				VoiceChannelId: e.VoiceChannelId,
+				Roll20GameId:   e.Roll20GameId,
+				Phase:          e.Phase,
+				Message:        e.Message,
+				DiscordKeys:    e.DiscordKeys,
+				Roll20Key:      e.Roll20Key,
+				Metadata:       metadata,
+				MixKey:         e.MixKey,
+				At:             e.At.UnixMilli(),
+			})
+			if err != nil {
+				slog.Error(fmt.Sprintf("[Server] :: Error watching records with params %+v, %s", req, err.Error()))
+				return err
+			}
+		}
+	}
+}
+
+func (s *server) ScheduleRecording(ctx context.Context, req *pb.ScheduleRecordRequest) (*pb.ScheduledRecording, error) {
+	if req.VoiceChannelId == "" {
+		return nil, services.ToStatus(fmt.Errorf("%w, voice channel id is required", services.ErrInvalidArgument))
+	}
+
+	slog.Info(fmt.Sprintf("[Server] :: Scheduling record with params %+v", req))
+	reply, err := s.scheduler.Schedule(ctx, req)
+	if err != nil {
+		slog.Error(fmt.Sprintf("[Server] :: Error scheduling record with params %+v, %s", req, err.Error()))
+	}
+	return reply, services.ToStatus(err)
+}
+
+func (s *server) ListSchedules(ctx context.Context, req *pb.ListSchedulesRequest) (*pb.ListSchedulesReply, error) {
+	reply, err := s.scheduler.List(ctx, req)
+	if err != nil {
+		slog.Error(fmt.Sprintf("[Server] :: Error listing schedules with params %+v, %s", req, err.Error()))
+	}
+	return reply, services.ToStatus(err)
+}
+
+func (s *server) CancelSchedule(ctx context.Context, req *pb.CancelScheduleRequest) (*pb.CancelScheduleReply, error) {
+	if req.Id == "" {
+		return nil, services.ToStatus(fmt.Errorf("%w, schedule id is required", services.ErrInvalidArgument))
+	}
+
+	slog.Info(fmt.Sprintf("[Server] :: Cancelling schedule with params %+v", req))
+	reply, err := s.scheduler.Cancel(ctx, req)
+	if err != nil {
+		slog.Error(fmt.Sprintf("[Server] :: Error cancelling schedule with params %+v, %s", req, err.Error()))
+	}
+	return reply, services.ToStatus(err)
+}
+
+func (s *server) Abort(ctx context.Context, req *pb.AbortRecordRequest) (*pb.AbortRecordReply, error) {
+	if req.VoiceChannelId == "" && req.SessionId == "" {
+		return nil, services.ToStatus(fmt.Errorf("%w, voice channel id or session id is required", services.ErrInvalidArgument))
+	}
+
+	slog.Warn(fmt.Sprintf("[Server] :: Aborting record with params %+v", req))
+	reply, err := s.service.Abort(ctx, req)
+	if err != nil {
+		slog.Error(fmt.Sprintf("[Server] :: Error aborting record with params %+v, %s", req, err.Error()))
+	}
+	return reply, services.ToStatus(err)
+}
+
+func (s *server) GetHealth(ctx context.Context, req *pb.GetHealthRequest) (*pb.GetHealthReply, error) {
+	return s.service.GetHealth(ctx, req)
 }
 
 func main() {
@@ -71,13 +237,41 @@ func main() {
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
-	s := grpc.NewServer()
+	subscribed, onSubscribed := subscriptionBarrier()
+	s := grpc.NewServer(grpc.UnaryInterceptor(onSubscribed))
 	daprServer := daprd.NewServiceWithGrpcServer(lis, s)
-	recorder, err := DI(daprServer, pEnv.daprGrpcPort)
+	recorder, scheduler, err := DI(daprServer, pEnv)
 	if err != nil {
 		panic(fmt.Errorf("failed to initialize event controller: %w", err))
 	}
-	pb.RegisterRecordServiceServer(s, &server{service: recorder})
+	pb.RegisterRecordServiceServer(s, &server{service: recorder, scheduler: scheduler})
+
+	// Expired idempotency keys are forgotten, whether sessions are reconciled or not
+	go every(FORGET_REPLIES_INTERVAL, "forget expired replies", recorder.ForgetReplies)
+
+	// Mix jobs followed before a restart are followed again
+	go func() {
+		if err := recorder.ResumeMixes(context.Background()); err != nil {
+			slog.Error(fmt.Sprintf("[Main] :: Could not resume unfinished mix jobs : %s", err.Error()))
+		}
+	}()
+
+	// Pandora replies are only received once Dapr subscribed to their topics.
+	// Until then, every call to Pandora would time out
+	go func() {
+		select {
+		case <-subscribed:
+			time.Sleep(SUBSCRIBE_DELAY)
+		case <-time.After(SUBSCRIBE_TIMEOUT):
+			slog.Warn("[Main] :: Dapr didn't list the subscriptions in time, Pandora may not be reachable yet")
+		}
+		// The orchestrator may have been restarted while recordings were scheduled
+		if err := scheduler.Load(context.Background()); err != nil {
+			slog.Error(fmt.Sprintf("[Main] :: Could not load scheduled recordings : %s", err.Error()))
+		}
+		// Or in the middle of a session
+		reconcile(recorder, pEnv.reconcileInterval)
+	}()
 
 	slog.Info(fmt.Sprintf("[Main] :: Starting gRPC server at %v", lis.Addr()))
 	if err := daprServer.Start(); err != nil {
@@ -93,8 +287,28 @@ type env struct {
 	serverPort int
 	// Dapr components ids
 	daprCpnPandora string
-	daprCpnR20     string
-	daprCpnState   string
+	// Where the lifecycle events are published, the Pandora pubsub component unless configured otherwise
+	daprCpnEvents string
+	daprCpnR20    string
+	daprCpnState  string
+	daprCpnMixer  string
+	// Interval between two reconciliations of the persisted sessions.
+	// Sessions are only reconciled at startup if 0
+	reconcileInterval time.Duration
+	// Default maximum duration of a session. No limit if 0
+	maxDuration time.Duration
+	// Recordings are pruned from the history once started this long ago. Kept forever if 0
+	historyRetention time.Duration
+	// JSON file describing the webhooks. Webhooks are disabled if empty
+	webhooksFile string
+	// Pandora is considered down once it sent no heartbeat for this long
+	heartbeatTimeout time.Duration
+	// Topics Pandora is driven through, the default ones if empty
+	pandoraTopics pando.Topics
+	// Metadata of the Pandora subscriptions and requests
+	pandoraMetadata map[string]string
+	// Version of the message schema Pandora must speak
+	pandoraSchemaVersion int
 }
 
 func parseEnv() *env {
@@ -104,6 +318,7 @@ func parseEnv() *env {
 		daprCpnPandora: DEFAULT_PUBSUB_ID,
 		daprCpnR20:     DEFAULT_R20_ID,
 		daprCpnState:   DEFAULT_STATE_STORE_ID,
+		maxDuration:    DEFAULT_MAX_DURATION,
 	}
 	if envPort, err := strconv.ParseInt(os.Getenv("DAPR_GRPC_PORT"), 10, 32); err == nil && envPort != 0 {
 		pEnv.daprGrpcPort = int(envPort)
@@ -114,32 +329,175 @@ func parseEnv() *env {
 	if id, isDefined := os.LookupEnv("PUBSUB_NAME"); isDefined && id != "" {
 		pEnv.daprCpnPandora = id
 	}
+	pEnv.daprCpnEvents = pEnv.daprCpnPandora
+	if id, isDefined := os.LookupEnv("EVENTS_PUBSUB_NAME"); isDefined && id != "" {
+		pEnv.daprCpnEvents = id
+	}
 	if id, isDefined := os.LookupEnv("ROLL20_NAME"); isDefined && id != "" {
 		pEnv.daprCpnR20 = id
 	}
 	if id, isDefined := os.LookupEnv("STORE_NAME"); isDefined && id != "" {
 		pEnv.daprCpnState = id
 	}
+	if id, isDefined := os.LookupEnv("MIXER_NAME"); isDefined && id != "" {
+		pEnv.daprCpnMixer = id
+	}
+	if interval, err := time.ParseDuration(os.Getenv("RECONCILE_INTERVAL")); err == nil && interval > 0 {
+		pEnv.reconcileInterval = interval
+	}
+	if maxDuration, err := time.ParseDuration(os.Getenv("MAX_DURATION")); err == nil && maxDuration >= 0 {
+		pEnv.maxDuration = maxDuration
+	}
+	if retention, err := time.ParseDuration(os.Getenv("HISTORY_RETENTION")); err == nil && retention > 0 {
+		pEnv.historyRetention = retention
+	}
+	if path, isDefined := os.LookupEnv("WEBHOOKS_FILE"); isDefined && path != "" {
+		pEnv.webhooksFile = path
+	}
+	if timeout, err := time.ParseDuration(os.Getenv("PANDORA_HEARTBEAT_TIMEOUT")); err == nil && timeout > 0 {
+		pEnv.heartbeatTimeout = timeout
+	}
+	for name, topic := range map[string]*string{
+		"PANDORA_TOPIC_START":        &pEnv.pandoraTopics.Start,
+		"PANDORA_TOPIC_STARTED":      &pEnv.pandoraTopics.Started,
+		"PANDORA_TOPIC_STOP":         &pEnv.pandoraTopics.Stop,
+		"PANDORA_TOPIC_STOPPED":      &pEnv.pandoraTopics.Stopped,
+		"PANDORA_TOPIC_STATUS":       &pEnv.pandoraTopics.Status,
+		"PANDORA_TOPIC_STATUSED":     &pEnv.pandoraTopics.Statused,
+		"PANDORA_TOPIC_PAUSE":        &pEnv.pandoraTopics.Pause,
+		"PANDORA_TOPIC_PAUSED":       &pEnv.pandoraTopics.Paused,
+		"PANDORA_TOPIC_RESUME":       &pEnv.pandoraTopics.Resume,
+		"PANDORA_TOPIC_RESUMED":      &pEnv.pandoraTopics.Resumed,
+		"PANDORA_TOPIC_DISCONNECTED": &pEnv.pandoraTopics.Disconnected,
+		"PANDORA_TOPIC_HEARTBEAT":    &pEnv.pandoraTopics.Heartbeat,
+	} {
+		if value, isDefined := os.LookupEnv(name); isDefined && value != "" {
+			*topic = value
+		}
+	}
+	// Comma-separated key=value pairs
+	if metadata, isDefined := os.LookupEnv("PANDORA_PUBSUB_METADATA"); isDefined && metadata != "" {
+		pEnv.pandoraMetadata = make(map[string]string)
+		for _, pair := range strings.Split(metadata, ",") {
+			if key, value, ok := strings.Cut(pair, "="); ok && key != "" {
+				pEnv.pandoraMetadata[strings.TrimSpace(key)] = strings.TrimSpace(value)
+			}
+		}
+	}
+	if version, err := strconv.Atoi(os.Getenv("PANDORA_SCHEMA_VERSION")); err == nil && version > 0 {
+		pEnv.pandoraSchemaVersion = version
+	}
 
 	return &pEnv
 }
 
-func DI(subServer common.Service, daprPort int) (*services.Recorder, error) {
+func DI(subServer common.Service, pEnv *env) (*services.Recorder, *services.Scheduler, error) {
 	// Dapr client, at the heart of everything
-	daprClient, err := makeDaprClient(daprPort, 16)
+	daprClient, err := makeDaprClient(pEnv.daprGrpcPort, 16)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// State store
-	store := memory.NewMemory[memory.State](daprClient, DEFAULT_STATE_STORE_ID)
+	store := memory.NewMemory[memory.State](daprClient, DEFAULT_STATE_STORE_ID, SESSIONS_NAMESPACE)
+	history := memory.NewMemory[memory.Record](daprClient, DEFAULT_STATE_STORE_ID, HISTORY_NAMESPACE)
+	schedules := memory.NewMemory[memory.Schedule](daprClient, DEFAULT_STATE_STORE_ID, SCHEDULES_NAMESPACE)
+	replies := memory.NewMemory[memory.Reply](daprClient, DEFAULT_STATE_STORE_ID, REPLIES_NAMESPACE)
 	// Recorders themselves
-	pandora, err := pando.NewPandora(daprClient, subServer, DEFAULT_PUBSUB_ID, pando.PandoraOpt{})
+	pandora, err := pando.NewPandora(daprClient, subServer, pEnv.daprCpnPandora, pando.PandoraOpt{
+		HeartbeatTimeout: pEnv.heartbeatTimeout,
+		Topics:           pEnv.pandoraTopics,
+		Metadata:         pEnv.pandoraMetadata,
+		SchemaVersion:    pEnv.pandoraSchemaVersion,
+	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	r20 := roll20_sync.NewRoll20Sync(daprClient, DEFAULT_R20_ID)
-	return services.NewRecorder(pandora, r20, store), nil
+	evts := events.NewEvents(daprClient, pEnv.daprCpnEvents)
+	var hooks events.Emitter
+	if pEnv.webhooksFile != "" {
+		config, err := webhooks.LoadWebhooks(pEnv.webhooksFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		deliveries := memory.NewMemory[webhooks.Delivery](daprClient, DEFAULT_STATE_STORE_ID, WEBHOOKS_NAMESPACE)
+		dispatcher := webhooks.NewDispatcher(config, deliveries, webhooks.DispatcherOpt{})
+		// Deliveries may have been interrupted by a restart
+		if err = dispatcher.Load(context.Background()); err != nil {
+			slog.Error(fmt.Sprintf("[Main] :: Could not resume pending webhook deliveries : %s", err.Error()))
+		}
+		go every(PRUNE_INTERVAL, "prune the webhooks delivery log", dispatcher.Prune)
+		hooks = dispatcher
+	}
+	// The mixer must implement the mix job contract, see the mixer package. Mixing is disabled otherwise
+	var audioMixer mixer.AudioMixer
+	if pEnv.daprCpnMixer != "" {
+		audioMixer = mixer.NewLiveAudioMixer(daprClient, pEnv.daprCpnMixer)
+	}
+	recorder := services.NewRecorder(pandora, r20, store, services.RecorderOpt{
+		Events:           evts,
+		Webhooks:         hooks,
+		History:          history,
+		HistoryRetention: pEnv.historyRetention,
+		MaxDuration:      pEnv.maxDuration,
+		Mixer:            audioMixer,
+		Replies:          replies,
+		Legacy:           memory.NewLegacy(daprClient, DEFAULT_STATE_STORE_ID),
+	})
+	if pEnv.historyRetention > 0 {
+		go every(PRUNE_INTERVAL, "prune the recordings history", recorder.PruneHistory)
+	}
+	pandora.OnInterrupted(recorder.Interrupt)
+	return recorder, services.NewScheduler(recorder, schedules), nil
+}
+
+// The returned channel is closed once Dapr listed the subscriptions of the app,
+// as noticed by the returned interceptor
+func subscriptionBarrier() (<-chan struct{}, grpc.UnaryServerInterceptor) {
+	subscribed := make(chan struct{})
+	var once sync.Once
+	return subscribed, func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		res, err := handler(ctx, req)
+		if info.FullMethod == LIST_SUBSCRIPTIONS_METHOD && err == nil {
+			once.Do(func() { close(subscribed) })
+		}
+		return res, err
+	}
+}
+
+// Reconcile the persisted sessions once, and then every interval if it isn't 0.
+// Otherwise, the sessions that could not be reconciled are retried until they all could
+func reconcile(recorder *services.Recorder, interval time.Duration) {
+	if interval > 0 {
+		for ; ; time.Sleep(interval) {
+			slog.Info("[Main] :: Reconciling persisted sessions")
+			if _, err := recorder.Reconcile(context.Background()); err != nil {
+				slog.Error(fmt.Sprintf("[Main] :: Reconciliation failed : %s", err.Error()))
+			}
+		}
+	}
+	slog.Info("[Main] :: Reconciling persisted sessions")
+	left, err := recorder.Reconcile(context.Background())
+	for err != nil {
+		slog.Error(fmt.Sprintf("[Main] :: Reconciliation failed, retrying in %s : %s", RECONCILE_RETRY, err.Error()))
+		time.Sleep(RECONCILE_RETRY)
+		// Without keys, the sessions could not even be listed
+		if left == nil {
+			left, err = recorder.Reconcile(context.Background())
+		} else {
+			left, err = recorder.ReconcileSessions(context.Background(), left)
+		}
+	}
+}
+
+// Run a background task every interval, logging its failures
+func every(interval time.Duration, task string, run func(ctx context.Context) error) {
+	for range time.Tick(interval) {
+		if err := run(context.Background()); err != nil {
+			slog.Error(fmt.Sprintf("[Main] :: Could not %s : %s", task, err.Error()))
+		}
+	}
 }
 
 func makeDaprClient(port, maxRequestSizeMB int) (client.Client, error) {
@@ -4,6 +4,7 @@ go 1.21
 
 require (
 	github.com/dapr/go-sdk v1.8.0
+	github.com/google/uuid v1.3.0
 	github.com/stretchr/testify v1.8.3
 	google.golang.org/grpc v1.58.0
 	google.golang.org/protobuf v1.31.0
@@ -13,7 +14,6 @@ require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/go-chi/chi/v5 v5.0.8 // indirect
 	github.com/golang/protobuf v1.5.3 // indirect
-	github.com/google/uuid v1.3.0 // indirect
 	github.com/kr/pretty v0.3.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/stretchr/objx v0.5.0 // indirect
@@ -9,6 +9,10 @@ import (
 )
 
 type PublishEventOption = dapr.PublishEventOption
+
+// PublishEventWithMetadata sets the metadata of a published event, passed as is to the pubsub component
+var PublishEventWithMetadata = dapr.PublishEventWithMetadata
+
 type Publisher interface {
 	PublishEvent(ctx context.Context, pubsubName string, topicName string, data interface{}, opts ...PublishEventOption) error
 }
@@ -21,10 +25,22 @@ type Invoker interface {
 	InvokeMethodWithContent(ctx context.Context, appID, method, verb string, content *DataContent) ([]byte, error)
 }
 
-type StateOption = dapr.StateOption
 type StateItem = dapr.StateItem
+type BulkStateItem = dapr.BulkStateItem
+type StateOperation = dapr.StateOperation
+type SetStateItem = dapr.SetStateItem
+type StateOptions = dapr.StateOptions
+type ETag = dapr.ETag
+
+const (
+	StateOperationTypeUpsert   = dapr.StateOperationTypeUpsert
+	StateOperationTypeDelete   = dapr.StateOperationTypeDelete
+	StateConcurrencyFirstWrite = dapr.StateConcurrencyFirstWrite
+)
+
 type StateSaver interface {
 	GetState(ctx context.Context, storeName string, key string, meta map[string]string) (item *StateItem, err error)
-	SaveState(ctx context.Context, storeName string, key string, data []byte, meta map[string]string, so ...StateOption) error
-	DeleteState(ctx context.Context, storeName string, key string, meta map[string]string) error
+	GetBulkState(ctx context.Context, storeName string, keys []string, meta map[string]string, parallelism int32) ([]*BulkStateItem, error)
+	// Every operation succeeds or none does
+	ExecuteStateTransaction(ctx context.Context, storeName string, meta map[string]string, ops []*StateOperation) error
 }
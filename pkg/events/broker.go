@@ -0,0 +1,63 @@
+package events
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// Number of events a subscriber can lag behind before missing some
+const SUBSCRIBER_BUFFER = 32
+
+// Broker dispatches events to in-process subscribers.
+// A slow subscriber never blocks the emitter, it misses events instead
+type Broker struct {
+	mu     sync.RWMutex
+	nextId int
+	subs   map[int]subscriber
+}
+
+type subscriber struct {
+	vcId string
+	ch   chan Event
+}
+
+func NewBroker() *Broker {
+	return &Broker{subs: make(map[int]subscriber)}
+}
+
+// Subscribe to the events of the voice channel vcId, or of every channel if empty.
+// The returned function cancels the subscription and closes the channel
+func (b *Broker) Subscribe(vcId string) (<-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	id := b.nextId
+	b.nextId++
+	ch := make(chan Event, SUBSCRIBER_BUFFER)
+	b.subs[id] = subscriber{vcId: vcId, ch: ch}
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.subs, id)
+			close(ch)
+		})
+	}
+}
+
+func (b *Broker) Emit(e Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for id, sub := range b.subs {
+		if sub.vcId != "" && sub.vcId != e.VoiceChannelId {
+			continue
+		}
+		select {
+		case sub.ch <- e:
+		default:
+			slog.Warn(fmt.Sprintf("[Broker] :: Subscriber %d is lagging behind, dropping event %s", id, e.Kind))
+		}
+	}
+	return nil
+}
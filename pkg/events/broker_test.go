@@ -0,0 +1,35 @@
+package events
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBroker_FilterByChannel(t *testing.T) {
+	b := NewBroker()
+	one, cancelOne := b.Subscribe("1")
+	defer cancelOne()
+	all, cancelAll := b.Subscribe("")
+	defer cancelAll()
+
+	assert.NoError(t, b.Emit(Event{Kind: Started, VoiceChannelId: "1"}))
+	assert.NoError(t, b.Emit(Event{Kind: Started, VoiceChannelId: "2"}))
+
+	assert.Equal(t, "1", (<-one).VoiceChannelId)
+	assert.Len(t, one, 0)
+	assert.Equal(t, "1", (<-all).VoiceChannelId)
+	assert.Equal(t, "2", (<-all).VoiceChannelId)
+}
+
+func TestBroker_SlowSubscriber(t *testing.T) {
+	b := NewBroker()
+	ch, cancel := b.Subscribe("")
+	// Never read, the emitter must not block
+	for i := 0; i < SUBSCRIBER_BUFFER*2; i++ {
+		assert.NoError(t, b.Emit(Event{Kind: Warning}))
+	}
+	assert.Len(t, ch, SUBSCRIBER_BUFFER)
+	cancel()
+	cancel()
+	assert.NoError(t, b.Emit(Event{Kind: Warning}))
+}
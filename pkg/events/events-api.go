@@ -0,0 +1,78 @@
+package events
+
+import "time"
+
+// Emitter publishes the events occurring during the lifecycle of recording sessions
+type Emitter interface {
+	Emit(e Event) error
+}
+
+// Kind of event, which is also the topic it is published on
+type Kind string
+
+const (
+	// A session was created and its sources are about to be started
+	StartRequested Kind = "startRequested"
+	// Pandora joined the voice channel and started recording
+	DiscordAcknowledged Kind = "discordAcknowledged"
+	// The Roll20 syncer started recording the game
+	Roll20Attached Kind = "roll20Attached"
+	// Every requested source is recording
+	Started Kind = "recordingStarted"
+	// Something went wrong, but the session goes on
+	Warning Kind = "warning"
+	// Every source of the session was paused
+	Paused Kind = "recordingPaused"
+	// Every source of the session was resumed
+	Resumed Kind = "recordingResumed"
+	// The sources of a session are about to be stopped
+	StopRequested Kind = "stopRequested"
+	// Pandora stopped recording and uploaded its tracks
+	TracksUploaded Kind = "tracksUploaded"
+	// The session ended normally
+	Stopped Kind = "recordingStopped"
+	// The session ended, but some of its sources failed along the way
+	PartiallyStopped Kind = "recordingPartiallyStopped"
+	// The session ended abnormally
+	Failed Kind = "recordingFailed"
+	// The session was forcibly ended by an administrator
+	Aborted Kind = "recordingAborted"
+	// Pandora stopped recording on its own, the session goes on until stopped
+	Interrupted Kind = "recordingInterrupted"
+	// A persisted session was reconciled with the actual state of its sources
+	Reconciled Kind = "recordingReconciled"
+	// The tracks of an ended session were mixed together
+	Mixed Kind = "recordingMixed"
+	// The tracks of an ended session could not be mixed
+	MixFailed Kind = "mixFailed"
+)
+
+type Event struct {
+	Kind           Kind   `json:"kind"`
+	SessionId      string `json:"sessionId,omitempty"`
+	VoiceChannelId string `json:"voiceChannelId"`
+	Roll20GameId   string `json:"roll20GameId,omitempty"`
+	// Phase of the session once the event occurred
+	Phase string `json:"phase"`
+	// What was decided about the session, if anything
+	Decision string `json:"decision,omitempty"`
+	// Human-readable explanation
+	Message     string   `json:"message,omitempty"`
+	DiscordKeys []string `json:"discordKeys,omitempty"`
+	Roll20Key   string   `json:"roll20Key,omitempty"`
+	// Offset of each track by key, in milliseconds. Before the tracks are uploaded,
+	// offset of each source by name instead
+	Offsets map[string]int64 `json:"offsets,omitempty"`
+	// Key of the mixed track, once mixed
+	MixKey   string    `json:"mixKey,omitempty"`
+	Metadata *Metadata `json:"metadata,omitempty"`
+	At       time.Time `json:"at"`
+}
+
+// Metadata the session was started with
+type Metadata struct {
+	Title         string            `json:"title,omitempty"`
+	Campaign      string            `json:"campaign,omitempty"`
+	SessionNumber int32             `json:"sessionNumber,omitempty"`
+	Labels        map[string]string `json:"labels,omitempty"`
+}
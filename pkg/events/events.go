@@ -0,0 +1,30 @@
+// Events are published on the pubsub component, each kind of event on its own topic
+package events
+
+import (
+	"context"
+	"record-orchestrator/internal/utils"
+)
+
+type Events struct {
+	client    utils.Publisher
+	component string
+}
+
+func NewEvents(client utils.Publisher, component string) *Events {
+	return &Events{
+		client:    client,
+		component: component,
+	}
+}
+
+func (e *Events) Emit(evt Event) error {
+	return e.client.PublishEvent(context.Background(), e.component, string(evt.Kind), evt)
+}
+
+// Discard is an Emitter dropping every event
+type Discard struct{}
+
+func (Discard) Emit(Event) error {
+	return nil
+}
@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"record-orchestrator/internal/utils"
+)
+
+// Key the versions predating the sessions namespace stored their single session under
+const LEGACY_SESSION_KEY = "recorder-state"
+
+// Legacy reads the session left in the state store by a version predating the sessions namespace
+type Legacy struct {
+	client    utils.StateSaver
+	component string
+}
+
+func NewLegacy(client utils.StateSaver, component string) *Legacy {
+	return &Legacy{client: client, component: component}
+}
+
+func (l *Legacy) Get(ctx context.Context) (*LegacySession, error) {
+	item, err := l.client.GetState(ctx, l.component, LEGACY_SESSION_KEY, map[string]string{})
+	if err != nil {
+		return nil, err
+	}
+	if item.Value == nil {
+		return nil, nil
+	}
+	var session LegacySession
+	if err = json.Unmarshal(item.Value, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (l *Legacy) Delete(ctx context.Context) error {
+	return l.client.ExecuteStateTransaction(ctx, l.component, map[string]string{}, []*utils.StateOperation{
+		{Type: utils.StateOperationTypeDelete, Item: &utils.SetStateItem{Key: LEGACY_SESSION_KEY}},
+	})
+}
@@ -0,0 +1,167 @@
+package memory
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Phase of a recording session lifecycle
+//
+//	                 Paused
+//	                 ^    |
+//	                 |    v
+//	Starting --> Recording --> Stopping --> Stopped
+//	   |             |            |
+//	   v             v            v
+//	Compensating -> Failed <------+
+//
+// A paused session can also be stopped or fail directly
+type Phase string
+
+const (
+	// Sources are being started
+	Starting Phase = "starting"
+	// Every requested source acknowledged the start
+	Recording Phase = "recording"
+	// Every source is paused, until resumed
+	Paused Phase = "paused"
+	// Sources are being stopped
+	Stopping Phase = "stopping"
+	// The session ended normally
+	Stopped Phase = "stopped"
+	// The session ended abnormally
+	Failed Phase = "failed"
+	// A start failed halfway and the started sources are being rolled back
+	Compensating Phase = "compensating"
+)
+
+// Phases each phase can legally move to
+var transitions = map[Phase][]Phase{
+	Starting:     {Recording, Compensating, Failed},
+	Recording:    {Paused, Stopping, Failed},
+	Paused:       {Recording, Stopping, Failed},
+	Stopping:     {Stopped, Failed},
+	Compensating: {Failed},
+	Stopped:      {},
+	Failed:       {},
+}
+
+type SourceStatus string
+
+const (
+	// The source isn't part of the session (yet)
+	SourceIdle SourceStatus = "idle"
+	// The source acknowledged the start
+	SourceRecording SourceStatus = "recording"
+	// The source acknowledged the stop
+	SourceStopped SourceStatus = "stopped"
+	// The source failed to start or stop
+	SourceFailed SourceStatus = "failed"
+	// The source stopped on its own, without being asked to
+	SourceInterrupted SourceStatus = "interrupted"
+)
+
+// ErrIllegalTransition is returned when moving a session to a phase
+// its current phase cannot lead to
+type ErrIllegalTransition struct {
+	From Phase
+	To   Phase
+}
+
+func (e *ErrIllegalTransition) Error() string {
+	return fmt.Sprintf("[Lifecycle] :: illegal transition, a %s session cannot be moved to %s", e.From, e.To)
+}
+
+// NewState creates the record of a session starting to record the voice channel vcId
+func NewState(vcId string) *State {
+	now := time.Now()
+	return &State{
+		Id:          uuid.NewString(),
+		VcId:        vcId,
+		Phase:       Starting,
+		Transitions: []Transition{{Phase: Starting, At: now}},
+		Discord:     Source{Status: SourceIdle, UpdatedAt: now},
+		Roll20:      Source{Status: SourceIdle, UpdatedAt: now},
+	}
+}
+
+// Transition moves the session to the phase to, recording when it happened.
+// An ErrIllegalTransition is returned if the current phase cannot lead to to
+func (s *State) Transition(to Phase) error {
+	if !s.CanTransition(to) {
+		return &ErrIllegalTransition{From: s.Phase, To: to}
+	}
+	s.Phase = to
+	s.Transitions = append(s.Transitions, Transition{Phase: to, At: time.Now()})
+	return nil
+}
+
+// CanTransition returns true if the session can move to the phase to
+func (s *State) CanTransition(to Phase) bool {
+	for _, next := range transitions[s.Phase] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsActive returns true if the session hasn't ended yet
+func (s *State) IsActive() bool {
+	return s.Phase != Stopped && s.Phase != Failed
+}
+
+// EnteredAt returns when the session last entered phase, and false if it never did
+func (s *State) EnteredAt(phase Phase) (time.Time, bool) {
+	for i := len(s.Transitions) - 1; i >= 0; i-- {
+		if s.Transitions[i].Phase == phase {
+			return s.Transitions[i].At, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// StartedAt returns when the session started recording. A session is only
+// considered started once every source acknowledged the start, but falls back
+// to its creation if it never got there. Resuming a paused session doesn't start it again
+func (s *State) StartedAt() time.Time {
+	for _, t := range s.Transitions {
+		if t.Phase == Recording {
+			return t.At
+		}
+	}
+	if len(s.Transitions) > 0 {
+		return s.Transitions[0].At
+	}
+	return time.Time{}
+}
+
+// Pause records that the session was paused at the given time
+func (s *State) Pause(at time.Time) {
+	s.Pauses = append(s.Pauses, Pause{Start: at})
+}
+
+// Resume closes the ongoing pause, if any, at the given time
+func (s *State) Resume(at time.Time) {
+	if n := len(s.Pauses); n > 0 && s.Pauses[n-1].End.IsZero() {
+		s.Pauses[n-1].End = at
+	}
+}
+
+// SetStatus updates the status of a source. The error, if any, is kept as the reason
+func (src *Source) SetStatus(status SourceStatus, err error) {
+	src.Status = status
+	src.Error = ""
+	if err != nil {
+		src.Error = err.Error()
+	}
+	src.UpdatedAt = time.Now()
+}
+
+// StartedAt estimates when the source actually started recording.
+// Without any other information, the request is assumed to take as long
+// to reach the source as the acknowledgement takes to come back
+func (src *Source) StartedAt() time.Time {
+	return src.RequestedAt.Add(src.AckAt.Sub(src.RequestedAt) / 2)
+}
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestState_FullLifecycle(t *testing.T) {
+	s := NewState("1")
+	assert.Equal(t, Starting, s.Phase)
+	assert.True(t, s.IsActive())
+	assert.NoError(t, s.Transition(Recording))
+	assert.NoError(t, s.Transition(Stopping))
+	assert.NoError(t, s.Transition(Stopped))
+	assert.False(t, s.IsActive())
+	assert.Len(t, s.Transitions, 4)
+	_, ok := s.EnteredAt(Recording)
+	assert.True(t, ok)
+	_, ok = s.EnteredAt(Failed)
+	assert.False(t, ok)
+}
+
+func TestState_StartedAtSurvivesResume(t *testing.T) {
+	s := NewState("1")
+	assert.Equal(t, s.Transitions[0].At, s.StartedAt())
+	assert.NoError(t, s.Transition(Recording))
+	startedAt := s.StartedAt()
+	assert.Equal(t, s.Transitions[1].At, startedAt)
+	assert.NoError(t, s.Transition(Paused))
+	assert.NoError(t, s.Transition(Recording))
+	assert.Equal(t, startedAt, s.StartedAt())
+}
+
+func TestState_IllegalTransition(t *testing.T) {
+	s := NewState("1")
+	err := s.Transition(Stopping)
+	var tErr *ErrIllegalTransition
+	assert.ErrorAs(t, err, &tErr)
+	assert.Equal(t, Starting, tErr.From)
+	assert.Equal(t, Stopping, tErr.To)
+	// A refused transition must leave the state untouched
+	assert.Equal(t, Starting, s.Phase)
+	assert.Len(t, s.Transitions, 1)
+}
+
+func TestState_TerminalPhases(t *testing.T) {
+	s := NewState("1")
+	assert.NoError(t, s.Transition(Compensating))
+	assert.NoError(t, s.Transition(Failed))
+	for _, p := range []Phase{Starting, Recording, Stopping, Stopped, Compensating} {
+		assert.False(t, s.CanTransition(p))
+	}
+}
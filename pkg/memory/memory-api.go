@@ -1,11 +1,209 @@
 package memory
 
+import (
+	"context"
+	"time"
+)
+
+// State is the lifecycle record of a recording session
 type State struct {
-	VcId  string
+	// Generated when the session is created, for clients to refer to it
+	Id string
+	// Discord voice channel being recorded
+	VcId string
+	// Roll20 game being recorded, empty if Roll20 isn't part of the session
 	R20Id string
+	// Roll20 game asked for when starting, even if Roll20 then failed to start
+	RequestedR20Id string `json:",omitempty"`
+	// Current phase of the session
+	Phase Phase
+	// Every phase the session went through, in order
+	Transitions []Transition
+	// Status of each recording source
+	Discord Source
+	Roll20  Source
+	// Pandora instance recording the voice channel. Empty if Pandora doesn't send heartbeats,
+	// in which case any instance handles the calls
+	PandoraInstance string `json:",omitempty"`
+	// Offset of each source from the earliest one to start recording, in milliseconds
+	Offsets map[string]int64 `json:",omitempty"`
+	// When the earliest source started recording, the reference of the offsets
+	Origin time.Time
+	// Every time the session was paused
+	Pauses []Pause `json:",omitempty"`
+	// Who asked for the recording
+	Requester string `json:",omitempty"`
+	// The session is stopped automatically once it lasted this long. No limit if 0
+	MaxDuration time.Duration `json:",omitempty"`
+	// Whether the session was stopped as it reached its maximum duration
+	AutoStopped bool `json:",omitempty"`
+	Metadata    Metadata
+	// Mix of the tracks, submitted when the session is stopped
+	Mix MixJob
+	// Why the session was aborted, if it was
+	AbortReason string `json:",omitempty"`
+	// Why each source could not be stopped when the session was aborted, by source
+	StopFailures map[string]string `json:",omitempty"`
+	// Tracks uploaded by Pandora when it stopped recording on its own
+	DiscordKeys []string `json:",omitempty"`
+}
+
+// MixJob is a job mixing the tracks of a session together
+type MixJob struct {
+	// Whether the tracks should be mixed once the session is stopped
+	Requested bool
+	// Id of the job, once submitted
+	Id       string  `json:",omitempty"`
+	Status   string  `json:",omitempty"`
+	Progress float32 `json:",omitempty"`
+	// Key of the mixed track, once done
+	Key   string `json:",omitempty"`
+	Error string `json:",omitempty"`
+}
+
+// Metadata is a free-form description of a recording, given when starting it
+type Metadata struct {
+	Title         string            `json:",omitempty"`
+	Campaign      string            `json:",omitempty"`
+	SessionNumber int32             `json:",omitempty"`
+	Labels        map[string]string `json:",omitempty"`
+}
+
+// Transition records when the session entered a phase
+type Transition struct {
+	Phase Phase
+	At    time.Time
+}
+
+// Pause is an interval during which the session was paused.
+// End is zero while the pause is ongoing
+type Pause struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Source is the status of a single recording source (Discord, Roll20)
+type Source struct {
+	Status SourceStatus
+	// Last error encountered by the source, if any
+	Error string `json:",omitempty"`
+	// Last time the status changed
+	UpdatedAt time.Time
+	// When the start request was sent to the source, and when it acknowledged it
+	RequestedAt time.Time
+	AckAt       time.Time
 }
+
 type StateStore interface {
-	Save(key string, value State) error
-	Get(key string) (*State, error)
-	Delete(key string) error
+	Save(ctx context.Context, key string, value State) error
+	Get(ctx context.Context, key string) (*State, error)
+	Delete(ctx context.Context, key string) error
+	// Keys of every stored session
+	Keys(ctx context.Context) ([]string, error)
+}
+
+// Record is the history entry of a session that ended
+type Record struct {
+	Id string
+	// Id of the session that was recorded
+	SessionId   string `json:",omitempty"`
+	VcId        string
+	R20Id       string
+	DiscordKeys []string
+	R20Key      string
+	// Offset of each track, by key, in milliseconds
+	Offsets   map[string]int64
+	StartedAt time.Time
+	StoppedAt time.Time
+	Requester string
+	// Pauses, relative to the earliest track start
+	Pauses []PauseOffset `json:",omitempty"`
+	// Whether the session was stopped as it reached its maximum duration
+	AutoStopped bool `json:",omitempty"`
+	Metadata    Metadata
+	Mix         MixJob
+	// Whether the session was aborted, and why
+	Aborted     bool   `json:",omitempty"`
+	AbortReason string `json:",omitempty"`
+	// Why each source could not be stopped when the session was aborted, by source
+	StopFailures map[string]string `json:",omitempty"`
+}
+
+// PauseOffset is a pause interval, in milliseconds from the earliest track start
+type PauseOffset struct {
+	Start int64
+	End   int64
+}
+
+type HistoryStore interface {
+	Save(ctx context.Context, key string, value Record) error
+	Get(ctx context.Context, key string) (*Record, error)
+	Delete(ctx context.Context, key string) error
+	// Keys of every recorded session
+	Keys(ctx context.Context) ([]string, error)
+}
+
+type ScheduleStatus string
+
+const (
+	// Waiting for its start time
+	SchedulePending ScheduleStatus = "pending"
+	// The recording could not be started
+	ScheduleFailed ScheduleStatus = "failed"
+)
+
+// Schedule is a recording planned in advance. Once started, the recording is
+// stopped by the watchdog after its maximum duration
+type Schedule struct {
+	Id          string
+	VcId        string
+	R20Id       string
+	StartAt     time.Time
+	MaxDuration time.Duration
+	Requester   string
+	Status      ScheduleStatus
+	// Why the schedule failed, if it did
+	Error    string `json:",omitempty"`
+	Metadata Metadata
+	// Whether to mix the tracks once the recording is stopped
+	Mix bool `json:",omitempty"`
+}
+
+type ScheduleStore interface {
+	Save(ctx context.Context, key string, value Schedule) error
+	Get(ctx context.Context, key string) (*Schedule, error)
+	Delete(ctx context.Context, key string) error
+	// Keys of every schedule
+	Keys(ctx context.Context) ([]string, error)
+}
+
+// Reply is the outcome of a successful call made with an idempotency key, kept so
+// that a retried call gets the same reply instead of being applied twice
+type Reply struct {
+	// Call the key was used for, either "start" or "stop"
+	Operation string
+	// The reply, in protobuf wire format
+	Payload []byte
+	At      time.Time
+}
+
+type ReplyStore interface {
+	Save(ctx context.Context, key string, value Reply) error
+	Get(ctx context.Context, key string) (*Reply, error)
+	Delete(ctx context.Context, key string) error
+	// Keys of every stored reply
+	Keys(ctx context.Context) ([]string, error)
+}
+
+// LegacySession is the single session stored by the versions predating the sessions namespace,
+// which was recording as long as it was stored
+type LegacySession struct {
+	VcId  string
+	R20Id string
+}
+
+type LegacyStore interface {
+	// The stored legacy session, nil if there is none
+	Get(ctx context.Context) (*LegacySession, error)
+	Delete(ctx context.Context) error
 }
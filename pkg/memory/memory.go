@@ -3,34 +3,51 @@ package memory
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
 	"record-orchestrator/internal/utils"
+	"slices"
 	"sync"
 )
 
+const (
+	// Shards of the index of each namespace. Adding or removing a key only rewrites its shard
+	INDEX_SHARDS = 16
+	// Attempts of a write before giving up, as another writer may update the same shard concurrently
+	MAX_WRITE_ATTEMPTS = 5
+)
+
+// Memory is a typed view over a Dapr state store.
+// Every key is stored under the namespace of the Memory, and an index
+// of these keys is maintained as the state store API doesn't allow listing them.
+// A key and its index shard are always written in the same transaction, guarded by
+// the ETag of the shard, so the state store must support transactions
 type Memory[S interface{}] struct {
 	client    utils.StateSaver
 	component string
+	namespace string
 	mu        sync.Mutex
 }
 
-func NewMemory[S interface{}](client utils.StateSaver, component string) *Memory[S] {
-	return &Memory[S]{client: client, component: component}
+func NewMemory[S interface{}](client utils.StateSaver, component string, namespace string) *Memory[S] {
+	return &Memory[S]{client: client, component: component, namespace: namespace}
 }
 
-func (m *Memory[S]) Save(key string, value S) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
+func (m *Memory[S]) Save(ctx context.Context, key string, value S) error {
 	bytes, err := json.Marshal(value)
 	if err != nil {
 		return err
 	}
-	return m.client.SaveState(context.Background(), m.component, key, bytes, map[string]string{})
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.write(ctx, key, bytes)
 }
 
-func (m *Memory[S]) Get(key string) (*S, error) {
+func (m *Memory[S]) Get(ctx context.Context, key string) (*S, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	item, err := m.client.GetState(context.Background(), m.component, key, map[string]string{})
+	item, err := m.client.GetState(ctx, m.component, m.keyOf(key), map[string]string{})
 	if err != nil {
 		return nil, err
 	}
@@ -45,8 +62,153 @@ func (m *Memory[S]) Get(key string) (*S, error) {
 	return &state, nil
 }
 
-func (m *Memory[S]) Delete(key string) error {
+func (m *Memory[S]) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.write(ctx, key, nil)
+}
+
+// Keys returns every key currently stored in the namespace
+func (m *Memory[S]) Keys(ctx context.Context) ([]string, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	return m.client.DeleteState(context.Background(), m.component, key, map[string]string{})
+	shards := make([]string, 0, INDEX_SHARDS)
+	for i := 0; i < INDEX_SHARDS; i++ {
+		shards = append(shards, m.shardKey(i))
+	}
+	items, err := m.client.GetBulkState(ctx, m.component, shards, map[string]string{}, INDEX_SHARDS)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0)
+	for _, item := range items {
+		if item.Error != "" {
+			return nil, fmt.Errorf("[Memory] :: Could not read index shard %s : %s", item.Key, item.Error)
+		}
+		shard, err := decodeKeys(item.Value)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, shard...)
+	}
+	return keys, nil
+}
+
+func (m *Memory[S]) keyOf(key string) string {
+	return fmt.Sprintf("%s-%s", m.namespace, key)
+}
+
+// Shards are stored under the namespace followed by their number, which can't collide with any key
+func (m *Memory[S]) shardKey(shard int) string {
+	return fmt.Sprintf("%s#%d", m.namespace, shard)
+}
+
+// Shard indexing key
+func shardOf(key string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % INDEX_SHARDS)
+}
+
+// Save a key, or delete it if value is nil, retrying when another writer updated it or its shard meanwhile
+func (m *Memory[S]) write(ctx context.Context, key string, value []byte) error {
+	var errs []error
+	for attempt := 0; attempt < MAX_WRITE_ATTEMPTS; attempt++ {
+		err := m.tryWrite(ctx, key, value)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return fmt.Errorf("[Memory] :: Could not write key %s : %w", key, errors.Join(errs...))
+}
+
+func (m *Memory[S]) tryWrite(ctx context.Context, key string, value []byte) error {
+	item, err := m.client.GetState(ctx, m.component, m.keyOf(key), map[string]string{})
+	if err != nil {
+		return err
+	}
+	existed := item.Value != nil
+	var ops []*utils.StateOperation
+	switch {
+	case value != nil:
+		ops = append(ops, upsert(m.keyOf(key), value, item.Etag))
+	case existed:
+		ops = append(ops, &utils.StateOperation{Type: utils.StateOperationTypeDelete, Item: &utils.SetStateItem{
+			Key: m.keyOf(key), Etag: etagOf(item.Etag), Options: firstWrite(),
+		}})
+	}
+	// The index only changes when a key appears or disappears.
+	// Deleting a missing key still makes sure it isn't indexed anymore
+	if !existed || value == nil {
+		shardOp, err := m.indexOp(ctx, key, value != nil)
+		if err != nil {
+			return err
+		}
+		if shardOp != nil {
+			ops = append(ops, shardOp)
+		}
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	return m.client.ExecuteStateTransaction(ctx, m.component, map[string]string{}, ops)
+}
+
+// Operation adding or removing key from its index shard, nil if the shard is already up to date
+func (m *Memory[S]) indexOp(ctx context.Context, key string, indexed bool) (*utils.StateOperation, error) {
+	shardKey := m.shardKey(shardOf(key))
+	item, err := m.client.GetState(ctx, m.component, shardKey, map[string]string{})
+	if err != nil {
+		return nil, err
+	}
+	keys, err := decodeKeys(item.Value)
+	if err != nil {
+		return nil, err
+	}
+	i := slices.Index(keys, key)
+	switch {
+	case indexed && i < 0:
+		keys = append(keys, key)
+	case !indexed && i >= 0:
+		keys = slices.Delete(keys, i, i+1)
+	default:
+		return nil, nil
+	}
+	bytes, err := json.Marshal(keys)
+	if err != nil {
+		return nil, err
+	}
+	return upsert(shardKey, bytes, item.Etag), nil
+}
+
+func decodeKeys(value []byte) ([]string, error) {
+	keys := make([]string, 0)
+	if value == nil {
+		return keys, nil
+	}
+	err := json.Unmarshal(value, &keys)
+	return keys, err
+}
+
+// Write value under key, only if key wasn't written since its ETag was read
+func upsert(key string, value []byte, etag string) *utils.StateOperation {
+	return &utils.StateOperation{Type: utils.StateOperationTypeUpsert, Item: &utils.SetStateItem{
+		Key: key, Value: value, Etag: etagOf(etag), Options: firstWrite(),
+	}}
+}
+
+// A missing key has no ETag
+func etagOf(etag string) *utils.ETag {
+	if etag == "" {
+		return nil
+	}
+	return &utils.ETag{Value: etag}
+}
+
+func firstWrite() *utils.StateOptions {
+	return &utils.StateOptions{Concurrency: utils.StateConcurrencyFirstWrite}
 }
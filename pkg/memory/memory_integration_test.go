@@ -25,15 +25,11 @@ func beforeAll() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	store = NewMemory[state](daprClient, DEFAULT_STATE_STORE_ID)
+	store = NewMemory[state](daprClient, DEFAULT_STATE_STORE_ID, "memory-test")
 }
 
 func teardown() {
-	daprClient, err := client.NewClientWithPort(DEFAULT_DAPR_PORT)
-	if err != nil {
-		log.Fatal(err)
-	}
-	err = daprClient.DeleteState(context.Background(), DEFAULT_STATE_STORE_ID, "test", nil)
+	err := store.Delete(context.Background(), "test")
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -42,24 +38,33 @@ func teardown() {
 func TestMemory_GetStateOk(t *testing.T) {
 	// State store
 	defer teardown()
-	err := store.Save("test", state{Val: "test"})
+	err := store.Save(context.Background(), "test", state{Val: "test"})
 	assert.NoError(t, err)
-	state, err := store.Get("test")
+	state, err := store.Get(context.Background(), "test")
 	assert.NoError(t, err)
 	assert.Equal(t, "test", state.Val)
 }
 
 func TestMemory_GetNoState(t *testing.T) {
 	defer teardown()
-	state, err := store.Get("test")
+	state, err := store.Get(context.Background(), "test")
 	assert.NoError(t, err)
 	assert.Nil(t, state)
 }
 
 func TestMemory_SaveState(t *testing.T) {
 	defer teardown()
-	err := store.Save("test", state{Val: "test"})
+	err := store.Save(context.Background(), "test", state{Val: "test"})
+	assert.NoError(t, err)
+}
+
+func TestMemory_Keys(t *testing.T) {
+	defer teardown()
+	err := store.Save(context.Background(), "test", state{Val: "test"})
+	assert.NoError(t, err)
+	keys, err := store.Keys(context.Background())
 	assert.NoError(t, err)
+	assert.Equal(t, []string{"test"}, keys)
 }
 
 func TestMain(m *testing.M) {
@@ -0,0 +1,159 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"record-orchestrator/internal/utils"
+	"strconv"
+	"testing"
+)
+
+type entry struct {
+	value []byte
+	etag  int
+}
+
+// Transactional state store, honouring the ETags of first-write operations
+type fakeStore struct {
+	entries map[string]entry
+	// Called before each transaction, to simulate concurrent writers
+	beforeTx func()
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{entries: make(map[string]entry)}
+}
+
+func (f *fakeStore) set(key string, value []byte) {
+	f.entries[key] = entry{value: value, etag: f.entries[key].etag + 1}
+}
+
+func (f *fakeStore) GetState(_ context.Context, _ string, key string, _ map[string]string) (*utils.StateItem, error) {
+	e, ok := f.entries[key]
+	if !ok {
+		return &utils.StateItem{Key: key}, nil
+	}
+	return &utils.StateItem{Key: key, Value: e.value, Etag: strconv.Itoa(e.etag)}, nil
+}
+
+func (f *fakeStore) GetBulkState(ctx context.Context, store string, keys []string, meta map[string]string, _ int32) ([]*utils.BulkStateItem, error) {
+	items := make([]*utils.BulkStateItem, 0, len(keys))
+	for _, key := range keys {
+		item, _ := f.GetState(ctx, store, key, meta)
+		items = append(items, &utils.BulkStateItem{Key: key, Value: item.Value, Etag: item.Etag})
+	}
+	return items, nil
+}
+
+func (f *fakeStore) ExecuteStateTransaction(_ context.Context, _ string, _ map[string]string, ops []*utils.StateOperation) error {
+	if f.beforeTx != nil {
+		f.beforeTx()
+	}
+	for _, op := range ops {
+		// Last write wins unless asked otherwise
+		if op.Item.Options == nil || op.Item.Options.Concurrency != utils.StateConcurrencyFirstWrite {
+			continue
+		}
+		e, exists := f.entries[op.Item.Key]
+		switch {
+		case op.Item.Etag == nil && exists:
+			return fmt.Errorf("etag mismatch on %s, expected none", op.Item.Key)
+		case op.Item.Etag != nil && (!exists || op.Item.Etag.Value != strconv.Itoa(e.etag)):
+			return fmt.Errorf("etag mismatch on %s", op.Item.Key)
+		}
+	}
+	for _, op := range ops {
+		if op.Type == utils.StateOperationTypeDelete {
+			delete(f.entries, op.Item.Key)
+		} else {
+			f.set(op.Item.Key, op.Item.Value)
+		}
+	}
+	return nil
+}
+
+func TestMemory_SaveIndexesKey(t *testing.T) {
+	client := newFakeStore()
+	mem := NewMemory[State](client, "store", "ns")
+	assert.NoError(t, mem.Save(context.Background(), "1", State{VcId: "1"}))
+	assert.NoError(t, mem.Save(context.Background(), "2", State{VcId: "2"}))
+
+	keys, err := mem.Keys(context.Background())
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"1", "2"}, keys)
+	state, err := mem.Get(context.Background(), "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", state.VcId)
+}
+
+// Updating a key that is already indexed leaves the index alone
+func TestMemory_UpdateKeepsIndex(t *testing.T) {
+	client := newFakeStore()
+	mem := NewMemory[State](client, "store", "ns")
+	assert.NoError(t, mem.Save(context.Background(), "1", State{VcId: "1"}))
+	shard := client.entries[mem.shardKey(shardOf("1"))]
+
+	assert.NoError(t, mem.Save(context.Background(), "1", State{VcId: "1", R20Id: "2"}))
+	assert.Equal(t, shard, client.entries[mem.shardKey(shardOf("1"))])
+	state, err := mem.Get(context.Background(), "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", state.R20Id)
+}
+
+func TestMemory_DeleteUnindexesKey(t *testing.T) {
+	client := newFakeStore()
+	mem := NewMemory[State](client, "store", "ns")
+	assert.NoError(t, mem.Save(context.Background(), "1", State{VcId: "1"}))
+	assert.NoError(t, mem.Delete(context.Background(), "1"))
+	// Deleting a missing key is a no-op
+	assert.NoError(t, mem.Delete(context.Background(), "1"))
+
+	keys, err := mem.Keys(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+	state, err := mem.Get(context.Background(), "1")
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+}
+
+// Another writer updating the same shard meanwhile must not lose its key, nor ours
+func TestMemory_ConcurrentWriter(t *testing.T) {
+	client := newFakeStore()
+	mem := NewMemory[State](client, "store", "ns")
+	other := NewMemory[State](client, "store", "ns")
+	client.beforeTx = func() {
+		client.beforeTx = nil
+		assert.NoError(t, other.Save(context.Background(), "1", State{VcId: "1"}))
+	}
+	// Same shard as "1"
+	key := "2"
+	for i := 2; shardOf(key) != shardOf("1"); i++ {
+		key = strconv.Itoa(i)
+	}
+	assert.NoError(t, mem.Save(context.Background(), key, State{VcId: key}))
+
+	keys, err := mem.Keys(context.Background())
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"1", key}, keys)
+}
+
+// The session left by a previous version doesn't get in the way of the sessions namespace
+func TestLegacy_Session(t *testing.T) {
+	client := newFakeStore()
+	client.set(LEGACY_SESSION_KEY, []byte(`{"VcId":"1","R20Id":"2"}`))
+	mem := NewMemory[State](client, "store", "recorder-sessions")
+	assert.NoError(t, mem.Save(context.Background(), "3", State{VcId: "3"}))
+	keys, err := mem.Keys(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"3"}, keys)
+
+	legacy := NewLegacy(client, "store")
+	session, err := legacy.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, &LegacySession{VcId: "1", R20Id: "2"}, session)
+	assert.NoError(t, legacy.Delete(context.Background()))
+	session, err = legacy.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, session)
+}
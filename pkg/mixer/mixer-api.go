@@ -0,0 +1,40 @@
+package mixer
+
+import "context"
+
+// AudioMixer mixes the tracks of a recording into a single one
+type AudioMixer interface {
+	// Mix submits a mixing job, returning its id. The job runs in the background
+	Mix(ctx context.Context, job Job) (string, error)
+	// Status of the job with the given id
+	Status(ctx context.Context, jobId string) (*JobStatus, error)
+}
+
+// Job lists the tracks to mix together
+type Job struct {
+	Tracks []Track `json:"tracks"`
+}
+
+// Track is a recorded object, starting Offset milliseconds after the earliest track
+type Track struct {
+	Key    string `json:"key"`
+	Offset int64  `json:"offset"`
+}
+
+type Status string
+
+const (
+	Pending Status = "pending"
+	Running Status = "running"
+	Done    Status = "done"
+	Failed  Status = "failed"
+)
+
+type JobStatus struct {
+	Status Status `json:"status"`
+	// Between 0 and 1
+	Progress float32 `json:"progress"`
+	// Key of the mixed object, once done
+	Key   string `json:"key,omitempty"`
+	Error string `json:"error,omitempty"`
+}
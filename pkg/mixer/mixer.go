@@ -0,0 +1,73 @@
+// The mixer is called through Dapr service invocation, with two methods taking and returning JSON.
+// As the mixer speaks gRPC to its sidecar, they reach its AppCallback OnInvoke handler :
+//   - mix, taking a Job and returning {"id": "<job id>"}
+//   - status, taking {"id": "<job id>"} and returning a JobStatus
+//
+// The mixer must implement this contract, which nothing guarantees the live audio mixer does,
+// hence mixing stays disabled until a mixer is configured
+package mixer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"record-orchestrator/internal/utils"
+)
+
+type LiveAudioMixer struct {
+	client    utils.Invoker
+	component string
+}
+
+type mixReply struct {
+	Id string `json:"id"`
+}
+
+type statusRequest struct {
+	Id string `json:"id"`
+}
+
+func NewLiveAudioMixer(client utils.Invoker, component string) *LiveAudioMixer {
+	return &LiveAudioMixer{
+		client:    client,
+		component: component,
+	}
+}
+
+func (m *LiveAudioMixer) Mix(ctx context.Context, job Job) (string, error) {
+	res, err := m.invoke(ctx, "mix", job)
+	if err != nil {
+		return "", err
+	}
+	reply := mixReply{}
+	err = json.Unmarshal(res, &reply)
+	if err != nil || reply.Id == "" {
+		return "", fmt.Errorf("[Mixer] :: Received wrong reply from the mixer %s, %v", res, err)
+	}
+	return reply.Id, nil
+}
+
+func (m *LiveAudioMixer) Status(ctx context.Context, jobId string) (*JobStatus, error) {
+	res, err := m.invoke(ctx, "status", statusRequest{Id: jobId})
+	if err != nil {
+		return nil, err
+	}
+	status := JobStatus{}
+	err = json.Unmarshal(res, &status)
+	if err != nil {
+		return nil, fmt.Errorf("[Mixer] :: Received wrong status from the mixer %s, %w", res, err)
+	}
+	return &status, nil
+}
+
+// Invoke a method of the mixer with a JSON payload
+func (m *LiveAudioMixer) invoke(ctx context.Context, method string, payload any) ([]byte, error) {
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return m.client.InvokeMethodWithContent(ctx, m.component, method, "POST", &utils.DataContent{
+		Data:        content,
+		ContentType: "application/json",
+	})
+}
@@ -0,0 +1,48 @@
+package mixer
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"record-orchestrator/internal/utils"
+	"testing"
+)
+
+// Invoker replying with the same payload to every call, and keeping the last request
+type fakeInvoker struct {
+	reply   []byte
+	method  string
+	request []byte
+}
+
+func (f *fakeInvoker) InvokeMethodWithContent(ctx context.Context, appID, method, verb string, content *utils.DataContent) ([]byte, error) {
+	f.method, f.request = method, content.Data
+	return f.reply, nil
+}
+
+func TestLiveAudioMixer_Mix(t *testing.T) {
+	invoker := &fakeInvoker{reply: []byte(`{"id":"job"}`)}
+	m := NewLiveAudioMixer(invoker, "mixer")
+	id, err := m.Mix(context.Background(), Job{Tracks: []Track{{Key: "a", Offset: 0}, {Key: "b.ogg", Offset: 300}}})
+	assert.NoError(t, err)
+	assert.Equal(t, "job", id)
+	assert.Equal(t, "mix", invoker.method)
+	assert.JSONEq(t, `{"tracks":[{"key":"a","offset":0},{"key":"b.ogg","offset":300}]}`, string(invoker.request))
+}
+
+func TestLiveAudioMixer_MixWrongReply(t *testing.T) {
+	m := NewLiveAudioMixer(&fakeInvoker{reply: []byte(`{}`)}, "mixer")
+	_, err := m.Mix(context.Background(), Job{})
+	assert.Error(t, err)
+}
+
+func TestLiveAudioMixer_Status(t *testing.T) {
+	reply, _ := json.Marshal(JobStatus{Status: Done, Progress: 1, Key: "mixed.ogg"})
+	invoker := &fakeInvoker{reply: reply}
+	m := NewLiveAudioMixer(invoker, "mixer")
+	status, err := m.Status(context.Background(), "job")
+	assert.NoError(t, err)
+	assert.Equal(t, &JobStatus{Status: Done, Progress: 1, Key: "mixed.ogg"}, status)
+	assert.Equal(t, "status", invoker.method)
+	assert.JSONEq(t, `{"id":"job"}`, string(invoker.request))
+}
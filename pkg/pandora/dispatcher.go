@@ -0,0 +1,81 @@
+package pandora
+
+import (
+	"github.com/google/uuid"
+	"sync"
+)
+
+// A request waiting for its reply
+type call struct {
+	// Correlation id of the request
+	id string
+	// Topic the reply is expected on
+	topic string
+	// Instance the request is sent to, any instance if empty
+	instanceId string
+	vcId       string
+	// Buffered, so that delivering a reply never blocks
+	reply chan PandoraReply
+}
+
+// Envelope of the request of the call
+func (c *call) envelope() Envelope {
+	return Envelope{CorrelationId: c.id, IsRequest: true, InstanceId: c.instanceId}
+}
+
+// dispatcher matches the replies of Pandora with the requests waiting for them,
+// allowing any number of requests to be in flight at once
+type dispatcher struct {
+	mu sync.Mutex
+	// Pending calls, oldest first
+	calls []*call
+}
+
+// Register a call to instanceId expecting a reply on topic about the voice channel vcId.
+// It must be forgotten once done waiting, whether the reply came or not
+func (d *dispatcher) register(topic string, instanceId string, vcId string) *call {
+	c := &call{id: uuid.NewString(), topic: topic, instanceId: instanceId, vcId: vcId, reply: make(chan PandoraReply, 1)}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.calls = append(d.calls, c)
+	return c
+}
+
+// Stop waiting for the reply of a call. A reply coming afterward won't match anything
+func (d *dispatcher) forget(c *call) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.remove(c)
+}
+
+// Deliver a reply received on topic to the call waiting for it, returning false if there is none.
+// A reply is matched by its correlation id, or else by the oldest call on the same voice channel.
+// Either way, the reply must come on the topic the call expects, and be about the same voice channel if it says
+func (d *dispatcher) deliver(topic string, correlationId string, vcId string, reply PandoraReply) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, c := range d.calls {
+		if correlationId != "" && c.id != correlationId {
+			continue
+		}
+		if c.topic != topic || (vcId != "" && c.vcId != vcId) {
+			if correlationId != "" {
+				return false
+			}
+			continue
+		}
+		d.remove(c)
+		c.reply <- reply
+		return true
+	}
+	return false
+}
+
+func (d *dispatcher) remove(c *call) {
+	for i, other := range d.calls {
+		if other == c {
+			d.calls = append(d.calls[:i], d.calls[i+1:]...)
+			return
+		}
+	}
+}
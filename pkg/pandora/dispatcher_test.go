@@ -0,0 +1,56 @@
+package pandora
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDispatcher_MatchesCorrelationId(t *testing.T) {
+	d := dispatcher{}
+	first := d.register(S_Started, "", "1")
+	second := d.register(S_Started, "", "1")
+
+	// Replies may come in any order
+	assert.True(t, d.deliver(S_Started, second.id, "1", PandoraReply{Started: &StartPandoraReply{VoiceChannelId: "second"}}))
+	assert.True(t, d.deliver(S_Started, first.id, "1", PandoraReply{Started: &StartPandoraReply{VoiceChannelId: "first"}}))
+	assert.Equal(t, "first", (<-first.reply).Started.VoiceChannelId)
+	assert.Equal(t, "second", (<-second.reply).Started.VoiceChannelId)
+	assert.Empty(t, d.calls)
+}
+
+func TestDispatcher_ChecksTopic(t *testing.T) {
+	d := dispatcher{}
+	stop := d.register(S_Ended, "", "1")
+
+	// A start reply cannot be taken for the reply of a stop
+	assert.False(t, d.deliver(S_Started, stop.id, "1", PandoraReply{}))
+	assert.False(t, d.deliver(S_Started, "", "1", PandoraReply{}))
+	// Nor can the reply about another voice channel
+	assert.False(t, d.deliver(S_Ended, stop.id, "2", PandoraReply{}))
+	assert.True(t, d.deliver(S_Ended, stop.id, "1", PandoraReply{}))
+}
+
+// Replies without a correlation id go to the oldest call of their voice channel
+func TestDispatcher_FallsBackToVoiceChannel(t *testing.T) {
+	d := dispatcher{}
+	other := d.register(S_Status, "", "2")
+	first := d.register(S_Status, "", "1")
+	second := d.register(S_Status, "", "1")
+
+	assert.True(t, d.deliver(S_Status, "", "1", PandoraReply{}))
+	assert.Len(t, first.reply, 1)
+	assert.Empty(t, second.reply)
+	assert.Empty(t, other.reply)
+	// Some replies don't say which voice channel they are about
+	assert.True(t, d.deliver(S_Status, "", "", PandoraReply{}))
+	assert.Len(t, other.reply, 1)
+}
+
+func TestDispatcher_DropsLateReplies(t *testing.T) {
+	d := dispatcher{}
+	c := d.register(S_Ended, "", "1")
+	d.forget(c)
+
+	assert.False(t, d.deliver(S_Ended, c.id, "1", PandoraReply{}))
+	assert.False(t, d.deliver(S_Ended, "", "1", PandoraReply{}))
+}
@@ -1,37 +1,305 @@
 package pandora
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Every call but Start is routed to the Pandora instance recording vcId, as returned by Start.
+// An empty instance id lets any instance handle the call
 type DiscordRecorder interface {
-	Start(vcId string) error
-	Stop(vcId string) ([]string, error)
+	// Start picks a free instance to record vcId and returns its id, even if it then failed to start
+	Start(ctx context.Context, vcId string) (string, error)
+	Stop(ctx context.Context, instanceId string, vcId string) ([]string, error)
+	// IsRecording probes Pandora to know whether vcId is being recorded
+	IsRecording(ctx context.Context, instanceId string, vcId string) (bool, error)
+	Pause(ctx context.Context, instanceId string, vcId string) error
+	Resume(ctx context.Context, instanceId string, vcId string) error
+	// Liveness tells whether Pandora is up, as told by its heartbeats
+	Liveness() Liveness
 }
 
 type topics string
 
+// Default topics, used unless configured otherwise
 const (
 	P_Start   topics = "startRecordingDiscord"
 	S_Started        = "startRecordingDiscord"
 	P_End            = "stopRecordingDiscord"
 	S_Ended          = "stoppedRecordingDiscord"
+	P_Status         = "statusRecordingDiscord"
+	S_Status         = "statusedRecordingDiscord"
+	P_Pause          = "pauseRecordingDiscord"
+	S_Paused         = "pausedRecordingDiscord"
+	P_Resume         = "resumeRecordingDiscord"
+	S_Resumed        = "resumedRecordingDiscord"
+	// Published by Pandora on its own, when it leaves a voice channel it was recording
+	S_Disconnected = "disconnectedRecordingDiscord"
+	// Published by every Pandora instance at regular intervals
+	S_Heartbeat = "heartbeatRecordingDiscord"
+)
+
+// Topics Pandora is driven through. A request and its reply may share a topic,
+// but every topic the orchestrator subscribes to must be different
+type Topics struct {
+	Start    string
+	Started  string
+	Stop     string
+	Stopped  string
+	Status   string
+	Statused string
+	Pause    string
+	Paused   string
+	Resume   string
+	Resumed  string
+	// Published by Pandora on its own
+	Disconnected string
+	Heartbeat    string
+}
+
+// DefaultTopics returns the topics used unless configured otherwise
+func DefaultTopics() Topics {
+	return Topics{
+		Start:        string(P_Start),
+		Started:      S_Started,
+		Stop:         P_End,
+		Stopped:      S_Ended,
+		Status:       P_Status,
+		Statused:     S_Status,
+		Pause:        P_Pause,
+		Paused:       S_Paused,
+		Resume:       P_Resume,
+		Resumed:      S_Resumed,
+		Disconnected: S_Disconnected,
+		Heartbeat:    S_Heartbeat,
+	}
+}
+
+// Fill the topics left empty with their default
+func (t Topics) withDefaults() Topics {
+	d := DefaultTopics()
+	for _, pair := range []struct{ topic, fallback *string }{
+		{&t.Start, &d.Start}, {&t.Started, &d.Started}, {&t.Stop, &d.Stop}, {&t.Stopped, &d.Stopped},
+		{&t.Status, &d.Status}, {&t.Statused, &d.Statused}, {&t.Pause, &d.Pause}, {&t.Paused, &d.Paused},
+		{&t.Resume, &d.Resume}, {&t.Resumed, &d.Resumed}, {&t.Disconnected, &d.Disconnected}, {&t.Heartbeat, &d.Heartbeat},
+	} {
+		if *pair.topic == "" {
+			*pair.topic = *pair.fallback
+		}
+	}
+	return t
+}
+
+// Every topic the orchestrator subscribes to must be different, as a subscription
+// only has one handler, and a reply would otherwise be taken for another one
+func (t Topics) validate() error {
+	subscribed := map[string]string{}
+	for _, pair := range []struct{ name, topic string }{
+		{"started", t.Started}, {"stopped", t.Stopped}, {"statused", t.Statused}, {"paused", t.Paused},
+		{"resumed", t.Resumed}, {"disconnected", t.Disconnected}, {"heartbeat", t.Heartbeat},
+	} {
+		if other, ok := subscribed[pair.topic]; ok {
+			return fmt.Errorf("[Pandora] :: topic %s is used for both %s and %s", pair.topic, other, pair.name)
+		}
+		subscribed[pair.topic] = pair.name
+	}
+	return nil
+}
+
+// Separates the topic of a request from the instance it is addressed to
+const INSTANCE_TOPIC_SEPARATOR = "."
+
+// InstanceTopic returns the topic the requests of a kind are published on when addressed to instanceId.
+// Instances subscribe to the shared topics as a single consumer group, so that a request that can be handled
+// by any instance is handled once. A request addressed to an instance would then reach an arbitrary one,
+// hence each instance also subscribes to its own topics
+func InstanceTopic(topic string, instanceId string) string {
+	if instanceId == "" {
+		return topic
+	}
+	return topic + INSTANCE_TOPIC_SEPARATOR + instanceId
+}
+
+// Version of the message schema the orchestrator speaks. Pandora messages without
+// a version predate the versioning of the schema, and are compatible with it
+const SCHEMA_VERSION = 1
+
+// Reason of an interruption, if Pandora didn't give any
+const (
+	DEFAULT_STOP_REASON       = "Pandora stopped recording on its own"
+	DEFAULT_DISCONNECT_REASON = "Pandora was disconnected from the voice channel"
 )
 
+// Envelope is shared by every request and reply, matching a reply with its request
+type Envelope struct {
+	// Generated for each request, and sent back by Pandora along with its reply.
+	// A reply without one is matched by its topic and voice channel instead
+	CorrelationId string `json:"correlationId,omitempty"`
+	// Only set on requests. As starts are requested and acknowledged on the
+	// same topic, this tells our own requests apart from the replies
+	IsRequest bool `json:"isRequest,omitempty"`
+	// On requests, the only instance that must handle it, any instance if empty.
+	// On replies, the instance replying
+	InstanceId string `json:"instanceId,omitempty"`
+	// Version of the message schema of the sender
+	SchemaVersion int `json:"schemaVersion,omitempty"`
+	// Only set on replies, when Pandora refused the request
+	Error *Error `json:"error,omitempty"`
+}
+
+// ErrTimeout is returned when Pandora didn't reply in time
+var ErrTimeout = errors.New("[Pandora] :: Timeout")
+
+// ErrUnavailable is returned without even asking when Pandora is known to be down
+var ErrUnavailable = errors.New("[Pandora] :: Pandora is down")
+
+// ErrNoInstance is returned when starting while every instance is already recording
+var ErrNoInstance = errors.New("[Pandora] :: every Pandora instance is busy")
+
+// ErrSchemaMismatch is returned when Pandora speaks another version of the message schema
+var ErrSchemaMismatch = errors.New("[Pandora] :: incompatible message schema")
+
+// ErrorCode tells why Pandora refused a request
+type ErrorCode string
+
+const (
+	// Pandora isn't allowed to join or speak in the voice channel
+	MissingPermissions ErrorCode = "missingPermissions"
+	// Pandora is already recording another voice channel
+	AlreadyRecording ErrorCode = "alreadyRecording"
+	// Pandora isn't recording the voice channel
+	NotRecording ErrorCode = "notRecording"
+	// The voice channel doesn't exist, or Pandora cannot see it
+	ChannelNotFound ErrorCode = "channelNotFound"
+	// Pandora could not connect to the voice channel
+	VoiceConnectionFailed ErrorCode = "voiceConnectionFailed"
+	// Anything else, including the codes unknown to the orchestrator
+	Internal ErrorCode = "internal"
+)
+
+// Error is the reply of Pandora to a request it refused
+type Error struct {
+	Code ErrorCode `json:"code"`
+	// Human-readable explanation
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s (%s)", e.Message, e.Code)
+}
+
 type StartPandoraRequest struct {
+	Envelope
 	VoiceChannelId string `json:"voiceChannelId"`
 }
 
 type StartPandoraReply struct {
+	Envelope
 	VoiceChannelId string `json:"voiceChannelId"`
 }
 
 type StopPandoraRequest struct {
+	Envelope
 	VoiceChannelId string `json:"voiceChannelId"`
 }
 
 type StopPandoraReply struct {
-	Ids []string `json:"ids"`
+	Envelope
+	// Not sent by every version of Pandora
+	VoiceChannelId string   `json:"voiceChannelId,omitempty"`
+	Ids            []string `json:"ids"`
+}
+
+type StatusPandoraRequest struct {
+	Envelope
+	VoiceChannelId string `json:"voiceChannelId"`
+}
+
+type StatusPandoraReply struct {
+	Envelope
+	VoiceChannelId string `json:"voiceChannelId"`
+	Recording      bool   `json:"recording"`
+}
+
+// Pause and resume share the same payloads
+type PausePandoraRequest struct {
+	Envelope
+	VoiceChannelId string `json:"voiceChannelId"`
+}
+
+type PausePandoraReply struct {
+	Envelope
+	VoiceChannelId string `json:"voiceChannelId"`
+}
+
+// Published when Pandora was kicked or disconnected from the voice channel it was recording
+type DisconnectedPandoraEvent struct {
+	Envelope
+	VoiceChannelId string `json:"voiceChannelId"`
+	Reason         string `json:"reason,omitempty"`
+	// Tracks Pandora managed to upload before leaving, if any
+	Ids []string `json:"ids,omitempty"`
+}
+
+// Interruption is Pandora stopping a recording nobody asked it to stop
+type Interruption struct {
+	// Instance that stopped, if it said
+	InstanceId     string
+	VoiceChannelId string
+	Reason         string
+	// Tracks Pandora managed to upload, if any
+	Ids []string
+}
+
+// InterruptionHandler is notified of every interruption
+type InterruptionHandler func(ctx context.Context, i Interruption) error
+
+// Published by every Pandora instance at regular intervals, to tell it is alive
+type HeartbeatPandoraEvent struct {
+	InstanceId string `json:"instanceId"`
+	Version    string `json:"version,omitempty"`
+	// Version of the message schema the instance speaks
+	SchemaVersion int `json:"schemaVersion,omitempty"`
+	// Voice channel the instance is recording, if any
+	VoiceChannelId string `json:"voiceChannelId,omitempty"`
+}
+
+type LivenessStatus string
+
+const (
+	// No heartbeat was received yet, Pandora may not send any
+	LivenessUnknown LivenessStatus = "unknown"
+	// At least one instance sent a heartbeat recently
+	LivenessUp LivenessStatus = "up"
+	// Every instance stopped sending heartbeats
+	LivenessDown LivenessStatus = "down"
+)
+
+// Instance is a Pandora instance that sent a heartbeat recently
+type Instance struct {
+	Id             string
+	Version        string
+	SchemaVersion  int
+	VoiceChannelId string
+	LastSeen       time.Time
+}
+
+// Liveness of Pandora, as told by the heartbeats of its instances
+type Liveness struct {
+	Status LivenessStatus
+	// Last heartbeat of any instance, zero if none was ever received
+	LastSeen  time.Time
+	Instances []Instance
+	// Version of the message schema the instances must speak
+	SchemaVersion int
 }
 
 type PandoraReply struct {
 	Started *StartPandoraReply
 	Stopped *StopPandoraReply
+	Status  *StatusPandoraReply
+	Paused  *PausePandoraReply
 	Error   error
 }
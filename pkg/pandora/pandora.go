@@ -5,6 +5,7 @@ package pandora
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/dapr/go-sdk/service/common"
 	"log/slog"
@@ -12,27 +13,55 @@ import (
 	"time"
 )
 
+// Pandora is expected to send a heartbeat every few seconds
+const DEFAULT_HEARTBEAT_TIMEOUT = 30 * time.Second
+
 type PandoraOpt struct {
 	WaitTimeout time.Duration
+	// Pandora is considered down once no instance sent a heartbeat for this long.
+	// DEFAULT_HEARTBEAT_TIMEOUT if 0
+	HeartbeatTimeout time.Duration
+	// Topics Pandora is driven through, the default ones for those left empty
+	Topics Topics
+	// Metadata of the subscriptions and of the published requests, passed as is to the pubsub component
+	Metadata map[string]string
+	// Version of the message schema Pandora must speak, SCHEMA_VERSION if 0
+	SchemaVersion int
 }
 type Pandora struct {
 	subServer utils.Subscriber
 	pubClient utils.Publisher
 	component string
-	replies   chan PandoraReply
-	opt       *PandoraOpt
+	// Matches the replies with the requests waiting for them
+	dispatcher *dispatcher
+	// Notified when Pandora stops recording on its own
+	onInterrupted InterruptionHandler
+	// Keeps track of the instances and of what they record
+	registry *registry
+	opt      *PandoraOpt
 }
 
 func NewPandora(pubClient utils.Publisher, subServer utils.Subscriber, component string, opt PandoraOpt) (*Pandora, error) {
 	if opt.WaitTimeout == 0 {
 		opt.WaitTimeout = time.Second * 30
 	}
+	if opt.HeartbeatTimeout == 0 {
+		opt.HeartbeatTimeout = DEFAULT_HEARTBEAT_TIMEOUT
+	}
+	if opt.SchemaVersion == 0 {
+		opt.SchemaVersion = SCHEMA_VERSION
+	}
+	opt.Topics = opt.Topics.withDefaults()
+	if err := opt.Topics.validate(); err != nil {
+		return nil, err
+	}
 	p := &Pandora{
-		pubClient: pubClient,
-		subServer: subServer,
-		component: component,
-		replies:   make(chan PandoraReply),
-		opt:       &opt,
+		pubClient:  pubClient,
+		subServer:  subServer,
+		component:  component,
+		dispatcher: &dispatcher{},
+		registry:   newRegistry(opt.HeartbeatTimeout, opt.SchemaVersion),
+		opt:        &opt,
 	}
 
 	err := p.subscribeTo(subServer)
@@ -48,7 +77,8 @@ func (p *Pandora) subscribeTo(subServer utils.Subscriber) error {
 	// Subscribe to the ACK after a recording request
 	err := subServer.AddTopicEventHandler(&common.Subscription{
 		PubsubName: p.component,
-		Topic:      S_Started,
+		Topic:      p.opt.Topics.Started,
+		Metadata:   p.opt.Metadata,
 	}, p.onStartedReply)
 	if err != nil {
 		return err
@@ -57,58 +87,291 @@ func (p *Pandora) subscribeTo(subServer utils.Subscriber) error {
 	// Subscribe to the reply after a stop record request
 	err = subServer.AddTopicEventHandler(&common.Subscription{
 		PubsubName: p.component,
-		Topic:      S_Ended,
+		Topic:      p.opt.Topics.Stopped,
+		Metadata:   p.opt.Metadata,
 	}, p.onStoppedReply)
 
 	if err != nil {
 		return err
 	}
 
+	// Subscribe to the reply after a status request
+	err = subServer.AddTopicEventHandler(&common.Subscription{
+		PubsubName: p.component,
+		Topic:      p.opt.Topics.Statused,
+		Metadata:   p.opt.Metadata,
+	}, p.onStatusReply)
+
+	if err != nil {
+		return err
+	}
+
+	// Subscribe to the replies after a pause or resume request
+	err = subServer.AddTopicEventHandler(&common.Subscription{
+		PubsubName: p.component,
+		Topic:      p.opt.Topics.Paused,
+		Metadata:   p.opt.Metadata,
+	}, p.onPausedReply)
+
+	if err != nil {
+		return err
+	}
+
+	err = subServer.AddTopicEventHandler(&common.Subscription{
+		PubsubName: p.component,
+		Topic:      p.opt.Topics.Resumed,
+		Metadata:   p.opt.Metadata,
+	}, p.onResumedReply)
+
+	if err != nil {
+		return err
+	}
+
+	// Subscribe to Pandora leaving a voice channel on its own
+	err = subServer.AddTopicEventHandler(&common.Subscription{
+		PubsubName: p.component,
+		Topic:      p.opt.Topics.Disconnected,
+		Metadata:   p.opt.Metadata,
+	}, p.onDisconnected)
+
+	if err != nil {
+		return err
+	}
+
+	// Subscribe to the heartbeats of the instances
+	err = subServer.AddTopicEventHandler(&common.Subscription{
+		PubsubName: p.component,
+		Topic:      p.opt.Topics.Heartbeat,
+		Metadata:   p.opt.Metadata,
+	}, p.onHeartbeat)
+
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
-// Start a new recording session
-func (p *Pandora) Start(vcId string) error {
-	// Pandora can only record a single voice channel at a time.
-	// In an effort to be completely stateless, we will let Pandora
-	// check the recording state
-	err := p.pubClient.PublishEvent(context.Background(), p.component, string(P_Start), StartPandoraRequest{
+// OnInterrupted sets the handler notified when Pandora stops a recording on its own,
+// either kicked from the voice channel or restarted. Interruptions are only logged until set
+func (p *Pandora) OnInterrupted(handler InterruptionHandler) {
+	p.onInterrupted = handler
+}
+
+// Start a new recording session, on an instance that isn't recording anything else
+func (p *Pandora) Start(ctx context.Context, vcId string) (string, error) {
+	// A Pandora instance can only record a single voice channel at a time.
+	// Heartbeats tell which instances are free, and an instance
+	// still refuses to start if it is recording something else
+	if l := p.Liveness(); l.Status == LivenessDown {
+		return "", fmt.Errorf("%w, no heartbeat since %s", ErrUnavailable, l.LastSeen.Format(time.RFC3339))
+	}
+	instanceId, err := p.registry.claim(vcId, time.Now())
+	if err != nil {
+		return "", err
+	}
+	c := p.dispatcher.register(p.opt.Topics.Started, instanceId, vcId)
+	defer p.dispatcher.forget(c)
+	err = p.publish(ctx, p.opt.Topics.Start, instanceId, StartPandoraRequest{
+		Envelope:       p.envelope(c),
 		VoiceChannelId: vcId,
 	})
-	if err != nil {
-		return err
+	if err == nil {
+		_, err = p.wait(ctx, c, "start")
 	}
-	select {
-	case <-time.After(p.opt.WaitTimeout):
-		err = fmt.Errorf("[Pandora] :: Timeout during initialization, could not start recording")
-	case reply := <-p.replies:
-		if reply.Error != nil {
-			err = fmt.Errorf("[Pandora] :: error during initialization, could not start recording : %w", reply.Error)
-		}
+	// The instance is left free if it surely didn't start. If it timed out or
+	// the caller gave up, it may have started anyway, and is released once stopped
+	var refused *Error
+	if err != nil && (errors.As(err, &refused) || (ctx.Err() == nil && !errors.Is(err, ErrTimeout))) {
+		p.registry.release(vcId)
 	}
-	return err
+	return instanceId, err
 }
 
-func (p *Pandora) Stop(vcId string) ([]string, error) {
-	err := p.pubClient.PublishEvent(context.Background(), p.component, P_End, StartPandoraRequest{
+func (p *Pandora) Stop(ctx context.Context, instanceId string, vcId string) ([]string, error) {
+	c := p.dispatcher.register(p.opt.Topics.Stopped, instanceId, vcId)
+	defer p.dispatcher.forget(c)
+	err := p.publish(ctx, p.opt.Topics.Stop, instanceId, StopPandoraRequest{
+		Envelope:       p.envelope(c),
 		VoiceChannelId: vcId,
 	})
 	if err != nil {
 		return []string{}, err
 	}
+	reply, err := p.wait(ctx, c, "end")
+	if err != nil {
+		return nil, err
+	}
+	p.registry.release(vcId)
+	return reply.Stopped.Ids, nil
+}
+
+// IsRecording asks Pandora whether vcId is currently being recorded
+func (p *Pandora) IsRecording(ctx context.Context, instanceId string, vcId string) (bool, error) {
+	c := p.dispatcher.register(p.opt.Topics.Statused, instanceId, vcId)
+	defer p.dispatcher.forget(c)
+	err := p.publish(ctx, p.opt.Topics.Status, instanceId, StatusPandoraRequest{
+		Envelope:       p.envelope(c),
+		VoiceChannelId: vcId,
+	})
+	if err != nil {
+		return false, err
+	}
+	reply, err := p.wait(ctx, c, "get the status of")
+	if err != nil {
+		return false, err
+	}
+	return reply.Status.Recording, nil
+}
+
+// Liveness tells whether Pandora is up. Pandora is only known to be down once
+// it sent heartbeats and stopped, as older versions of Pandora don't send any
+func (p *Pandora) Liveness() Liveness {
+	return p.registry.liveness(time.Now())
+}
+
+// Pause the recording of vcId, until resumed
+func (p *Pandora) Pause(ctx context.Context, instanceId string, vcId string) error {
+	return p.togglePause(ctx, p.opt.Topics.Pause, p.opt.Topics.Paused, instanceId, vcId, "pause")
+}
+
+// Resume the paused recording of vcId
+func (p *Pandora) Resume(ctx context.Context, instanceId string, vcId string) error {
+	return p.togglePause(ctx, p.opt.Topics.Resume, p.opt.Topics.Resumed, instanceId, vcId, "resume")
+}
+
+func (p *Pandora) togglePause(ctx context.Context, topic string, replyTopic string, instanceId string, vcId string, action string) error {
+	c := p.dispatcher.register(replyTopic, instanceId, vcId)
+	defer p.dispatcher.forget(c)
+	err := p.publish(ctx, topic, instanceId, PausePandoraRequest{
+		Envelope:       p.envelope(c),
+		VoiceChannelId: vcId,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = p.wait(ctx, c, action)
+	return err
+}
+
+// Envelope of the request of a call
+func (p *Pandora) envelope(c *call) Envelope {
+	e := c.envelope()
+	e.SchemaVersion = p.opt.SchemaVersion
+	return e
+}
+
+// Publish a request addressed to instanceId, or to any instance if empty, along with the configured metadata
+func (p *Pandora) publish(ctx context.Context, topic string, instanceId string, data interface{}) error {
+	var opts []utils.PublishEventOption
+	if len(p.opt.Metadata) > 0 {
+		opts = append(opts, utils.PublishEventWithMetadata(p.opt.Metadata))
+	}
+	return p.pubClient.PublishEvent(ctx, p.component, InstanceTopic(topic, instanceId), data, opts...)
+}
+
+// Error telling Pandora speaks another version of the message schema
+func (p *Pandora) mismatch(schemaVersion int) error {
+	return fmt.Errorf("%w, Pandora speaks version %d but version %d is expected", ErrSchemaMismatch, schemaVersion, p.opt.SchemaVersion)
+}
 
-	var ids []string
+// Wait for the reply of a call, until Pandora timed out or the caller gave up
+func (p *Pandora) wait(ctx context.Context, c *call, action string) (PandoraReply, error) {
 	select {
 	case <-time.After(p.opt.WaitTimeout):
-		err = fmt.Errorf("[Pandora] :: Timeout, could not end recording")
-	case reply := <-p.replies:
+		return PandoraReply{}, fmt.Errorf("%w, could not %s recording", ErrTimeout, action)
+	case <-ctx.Done():
+		return PandoraReply{}, fmt.Errorf("[Pandora] :: gave up waiting to %s recording : %w", action, ctx.Err())
+	case reply := <-c.reply:
 		if reply.Error != nil {
-			err = fmt.Errorf("[Pandora] :: could not end recording : %w", reply.Error)
-		} else {
-			ids = reply.Stopped.Ids
+			return reply, fmt.Errorf("[Pandora] :: could not %s recording : %w", action, reply.Error)
+		}
+		return reply, nil
+	}
+}
+
+// Hand a reply received on topic over to the request waiting for it.
+// Our own requests published on the same topic are ignored, and so are
+// the replies no request is waiting for anymore, typically as it timed out.
+// A stop without correlation id nobody asked for is Pandora stopping on its own
+func (p *Pandora) dispatch(ctx context.Context, topic string, envelope Envelope, vcId string, reply PandoraReply) {
+	if envelope.IsRequest {
+		return
+	}
+	if reply.Error == nil && envelope.Error != nil {
+		reply.Error = envelope.Error
+	}
+	// The reply cannot be trusted, but the request waiting for it can still fail right away
+	compatible := p.registry.compatible(envelope.SchemaVersion)
+	if !compatible {
+		reply.Error = p.mismatch(envelope.SchemaVersion)
+		slog.Error(fmt.Sprintf("[Pandora] :: Reply on %s for voice channel %s : %s", topic, vcId, reply.Error.Error()))
+	}
+	if p.dispatcher.deliver(topic, envelope.CorrelationId, vcId, reply) || !compatible {
+		return
+	}
+	if topic == p.opt.Topics.Stopped && envelope.CorrelationId == "" && vcId != "" && reply.Error == nil {
+		p.interrupt(ctx, Interruption{InstanceId: envelope.InstanceId, VoiceChannelId: vcId, Reason: DEFAULT_STOP_REASON, Ids: reply.Stopped.Ids})
+		return
+	}
+	slog.Warn(fmt.Sprintf("[Pandora] :: Dropping unexpected reply on %s for voice channel %s (correlation id %q)", topic, vcId, envelope.CorrelationId))
+}
+
+// Notify the interruption handler that Pandora stopped recording on its own
+func (p *Pandora) interrupt(ctx context.Context, i Interruption) {
+	slog.Warn(fmt.Sprintf("[Pandora] :: Recording of voice channel %s interrupted : %s, %d track(s) uploaded", i.VoiceChannelId, i.Reason, len(i.Ids)))
+	p.registry.release(i.VoiceChannelId)
+	if p.onInterrupted == nil {
+		return
+	}
+	if err := p.onInterrupted(ctx, i); err != nil {
+		slog.Error(fmt.Sprintf("[Pandora] :: Could not handle the interruption of voice channel %s : %s", i.VoiceChannelId, err.Error()))
+	}
+}
+
+func (p *Pandora) onHeartbeat(ctx context.Context, e *common.TopicEvent) (retry bool, err error) {
+	hb := HeartbeatPandoraEvent{}
+	err = json.Unmarshal(e.RawData, &hb)
+	if err != nil {
+		err = fmt.Errorf("[Pandora] :: Received wrong heartbeat from pandora %+v, %w", hb, err)
+		slog.Error(err.Error())
+		return false, err
+	}
+	if hb.InstanceId == "" {
+		slog.Warn(fmt.Sprintf("[Pandora] :: Dropping heartbeat without instance id %+v", hb))
+		return false, nil
+	}
+	if p.registry.beat(hb, time.Now()) {
+		slog.Info(fmt.Sprintf("[Pandora] :: Instance %s (version %s, schema version %d) is up", hb.InstanceId, hb.Version, hb.SchemaVersion))
+		if !p.registry.compatible(hb.SchemaVersion) {
+			slog.Error(fmt.Sprintf("[Pandora] :: Instance %s won't be used : %s", hb.InstanceId, p.mismatch(hb.SchemaVersion).Error()))
 		}
 	}
-	return ids, err
+	return false, nil
+}
+
+func (p *Pandora) onDisconnected(ctx context.Context, e *common.TopicEvent) (retry bool, err error) {
+	evt := DisconnectedPandoraEvent{}
+	err = json.Unmarshal(e.RawData, &evt)
+	if err != nil {
+		err = fmt.Errorf("[Pandora] :: Received wrong event type from pandora %+v, %w", evt, err)
+		slog.Error(err.Error())
+		return false, err
+	}
+	if !p.registry.compatible(evt.SchemaVersion) {
+		err = p.mismatch(evt.SchemaVersion)
+		slog.Error(fmt.Sprintf("[Pandora] :: Dropping disconnection %+v : %s", evt, err.Error()))
+		return false, err
+	}
+	if evt.VoiceChannelId == "" {
+		slog.Warn(fmt.Sprintf("[Pandora] :: Dropping disconnection without voice channel %+v", evt))
+		return false, nil
+	}
+	if evt.Reason == "" {
+		evt.Reason = DEFAULT_DISCONNECT_REASON
+	}
+	p.interrupt(ctx, Interruption{InstanceId: evt.InstanceId, VoiceChannelId: evt.VoiceChannelId, Reason: evt.Reason, Ids: evt.Ids})
+	return false, nil
 }
 
 func (p *Pandora) onStoppedReply(ctx context.Context, e *common.TopicEvent) (retry bool, err error) {
@@ -118,11 +381,10 @@ func (p *Pandora) onStoppedReply(ctx context.Context, e *common.TopicEvent) (ret
 		err = fmt.Errorf("[Pandora] :: Received wrong response type from pandora %+v, %w", reply, err)
 		slog.Error(err.Error())
 	}
-	p.replies <- PandoraReply{
-		Started: nil,
+	p.dispatch(ctx, p.opt.Topics.Stopped, reply.Envelope, reply.VoiceChannelId, PandoraReply{
 		Stopped: &reply,
 		Error:   err,
-	}
+	})
 	return false, err
 }
 
@@ -133,10 +395,45 @@ func (p *Pandora) onStartedReply(ctx context.Context, e *common.TopicEvent) (ret
 		err = fmt.Errorf("[Pandora] :: Received wrong response type from pandora %+v, %w", reply, err)
 		slog.Error(err.Error())
 	}
-	p.replies <- PandoraReply{
+	p.dispatch(ctx, p.opt.Topics.Started, reply.Envelope, reply.VoiceChannelId, PandoraReply{
 		Started: &reply,
-		Stopped: nil,
 		Error:   err,
+	})
+	return false, err
+}
+
+func (p *Pandora) onStatusReply(ctx context.Context, e *common.TopicEvent) (retry bool, err error) {
+	reply := StatusPandoraReply{}
+	err = json.Unmarshal(e.RawData, &reply)
+	if err != nil {
+		err = fmt.Errorf("[Pandora] :: Received wrong response type from pandora %+v, %w", reply, err)
+		slog.Error(err.Error())
 	}
+	p.dispatch(ctx, p.opt.Topics.Statused, reply.Envelope, reply.VoiceChannelId, PandoraReply{
+		Status: &reply,
+		Error:  err,
+	})
+	return false, err
+}
+
+func (p *Pandora) onPausedReply(ctx context.Context, e *common.TopicEvent) (retry bool, err error) {
+	return p.onToggledReply(ctx, p.opt.Topics.Paused, e)
+}
+
+func (p *Pandora) onResumedReply(ctx context.Context, e *common.TopicEvent) (retry bool, err error) {
+	return p.onToggledReply(ctx, p.opt.Topics.Resumed, e)
+}
+
+func (p *Pandora) onToggledReply(ctx context.Context, topic string, e *common.TopicEvent) (retry bool, err error) {
+	reply := PausePandoraReply{}
+	err = json.Unmarshal(e.RawData, &reply)
+	if err != nil {
+		err = fmt.Errorf("[Pandora] :: Received wrong response type from pandora %+v, %w", reply, err)
+		slog.Error(err.Error())
+	}
+	p.dispatch(ctx, topic, reply.Envelope, reply.VoiceChannelId, PandoraReply{
+		Paused: &reply,
+		Error:  err,
+	})
 	return false, err
 }
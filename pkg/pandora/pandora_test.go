@@ -54,7 +54,7 @@ func TestPandora_OnStartedReply_Ok(t *testing.T) {
 			done <- true
 		}
 	}()
-	err = p.Start("1")
+	_, err = p.Start(context.Background(), "1")
 	pub.AssertExpectations(t)
 	sub.AssertExpectations(t)
 	assert.NoError(t, err)
@@ -79,7 +79,7 @@ func TestPandora_OnStartedReply_WrongReply(t *testing.T) {
 			done <- true
 		}
 	}()
-	err = p.Start("1")
+	_, err = p.Start(context.Background(), "1")
 	pub.AssertExpectations(t)
 	sub.AssertExpectations(t)
 	assert.Error(t, err)
@@ -102,12 +102,27 @@ func TestPandora_OnStartedReply_Timeout(t *testing.T) {
 			assert.Error(t, err)
 		}
 	}()
-	err = p.Start("1")
+	_, err = p.Start(context.Background(), "1")
 	pub.AssertExpectations(t)
 	sub.AssertExpectations(t)
 	assert.Error(t, err)
 }
 
+// The caller gave up before Pandora replied
+func TestPandora_Start_Cancelled(t *testing.T) {
+	pub := mockPublisher{}
+	sub := mockSubscriber{}
+	sub.On("AddTopicEventHandler", mock.Anything, mock.Anything).Return(nil)
+	pub.On("PublishEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	p, err := NewPandora(&pub, &sub, "", PandoraOpt{})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = p.Start(ctx, "1")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
 func TestPandora_OnStoppedReply_Timeout(t *testing.T) {
 	pub := mockPublisher{}
 	sub := mockSubscriber{}
@@ -124,7 +139,7 @@ func TestPandora_OnStoppedReply_Timeout(t *testing.T) {
 			assert.Error(t, err)
 		}
 	}()
-	_, err = p.Stop("1")
+	_, err = p.Stop(context.Background(), "", "1")
 	pub.AssertExpectations(t)
 	sub.AssertExpectations(t)
 	assert.Error(t, err)
@@ -147,7 +162,7 @@ func TestPandora_OnStoppedReply_WrongReply(t *testing.T) {
 			done <- true
 		}
 	}()
-	_, err = p.Stop("1")
+	_, err = p.Stop(context.Background(), "", "1")
 	assert.Error(t, err)
 	pub.AssertExpectations(t)
 	sub.AssertExpectations(t)
@@ -175,10 +190,382 @@ func TestPandora_OnStoppedReply_Ok(t *testing.T) {
 			done <- true
 		}
 	}()
-	res, err := p.Stop("1")
+	res, err := p.Stop(context.Background(), "", "1")
 	assert.NoError(t, err)
 	assert.Equal(t, ids, res)
 	pub.AssertExpectations(t)
 	sub.AssertExpectations(t)
 	<-done
 }
+
+func TestPandora_OnStatusReply_Ok(t *testing.T) {
+	pub := mockPublisher{}
+	sub := mockSubscriber{}
+	sub.On("AddTopicEventHandler", mock.Anything, mock.Anything).Return(nil)
+	pub.On("PublishEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	p, err := NewPandora(&pub, &sub, "", PandoraOpt{})
+	assert.NoError(t, err)
+
+	payload, err := json.Marshal(StatusPandoraReply{VoiceChannelId: "1", Recording: true})
+	assert.NoError(t, err)
+	done := make(chan bool)
+	go func() {
+		select {
+		case <-time.After(1 * time.Second):
+			ok, err := p.onStatusReply(context.Background(), &common.TopicEvent{RawData: payload})
+			assert.False(t, ok)
+			assert.NoError(t, err)
+			done <- true
+		}
+	}()
+	recording, err := p.IsRecording(context.Background(), "", "1")
+	assert.NoError(t, err)
+	assert.True(t, recording)
+	pub.AssertExpectations(t)
+	sub.AssertExpectations(t)
+	<-done
+}
+
+func TestPandora_OnPausedReply_Ok(t *testing.T) {
+	pub := mockPublisher{}
+	sub := mockSubscriber{}
+	sub.On("AddTopicEventHandler", mock.Anything, mock.Anything).Return(nil)
+	pub.On("PublishEvent", mock.Anything, mock.Anything, P_Pause, mock.Anything, mock.Anything).Return(nil)
+	p, err := NewPandora(&pub, &sub, "", PandoraOpt{})
+	assert.NoError(t, err)
+
+	payload, err := json.Marshal(PausePandoraReply{VoiceChannelId: "1"})
+	assert.NoError(t, err)
+	done := make(chan bool)
+	go func() {
+		select {
+		case <-time.After(1 * time.Second):
+			ok, err := p.onPausedReply(context.Background(), &common.TopicEvent{RawData: payload})
+			assert.False(t, ok)
+			assert.NoError(t, err)
+			done <- true
+		}
+	}()
+	err = p.Pause(context.Background(), "", "1")
+	assert.NoError(t, err)
+	pub.AssertExpectations(t)
+	<-done
+}
+
+// Starts are requested and acknowledged on the same topic
+func TestPandora_IgnoresOwnRequest(t *testing.T) {
+	pub := mockPublisher{}
+	sub := mockSubscriber{}
+	sub.On("AddTopicEventHandler", mock.Anything, mock.Anything).Return(nil)
+	p, err := NewPandora(&pub, &sub, "", PandoraOpt{WaitTimeout: 200 * time.Millisecond})
+	assert.NoError(t, err)
+	pub.On("PublishEvent", mock.Anything, mock.Anything, string(P_Start), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		payload, _ := json.Marshal(args.Get(3))
+		_, err := p.onStartedReply(context.Background(), &common.TopicEvent{RawData: payload})
+		assert.NoError(t, err)
+	}).Return(nil)
+
+	// Our own request isn't taken for Pandora's reply
+	_, err = p.Start(context.Background(), "1")
+	assert.ErrorContains(t, err, "Timeout")
+}
+
+func TestPandora_ConcurrentRequests(t *testing.T) {
+	pub := mockPublisher{}
+	sub := mockSubscriber{}
+	sub.On("AddTopicEventHandler", mock.Anything, mock.Anything).Return(nil)
+	p, err := NewPandora(&pub, &sub, "", PandoraOpt{})
+	assert.NoError(t, err)
+	requests := make(chan interface{}, 2)
+	pub.On("PublishEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		requests <- args.Get(3)
+	}).Return(nil)
+
+	stopped := make(chan []string)
+	go func() {
+		ids, err := p.Stop(context.Background(), "", "1")
+		assert.NoError(t, err)
+		stopped <- ids
+	}()
+	recording := make(chan bool)
+	go func() {
+		status, err := p.IsRecording(context.Background(), "", "2")
+		assert.NoError(t, err)
+		recording <- status
+	}()
+
+	// Reply to both requests, whatever the order they were sent in
+	for i := 0; i < 2; i++ {
+		switch req := (<-requests).(type) {
+		case StopPandoraRequest:
+			payload, _ := json.Marshal(StopPandoraReply{Envelope: Envelope{CorrelationId: req.CorrelationId}, Ids: []string{"a"}})
+			_, err = p.onStoppedReply(context.Background(), &common.TopicEvent{RawData: payload})
+		case StatusPandoraRequest:
+			payload, _ := json.Marshal(StatusPandoraReply{Envelope: Envelope{CorrelationId: req.CorrelationId}, VoiceChannelId: "2", Recording: true})
+			_, err = p.onStatusReply(context.Background(), &common.TopicEvent{RawData: payload})
+		}
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []string{"a"}, <-stopped)
+	assert.True(t, <-recording)
+}
+
+// A reply coming after its request timed out doesn't block the subscription
+func TestPandora_DropsLateReply(t *testing.T) {
+	pub := mockPublisher{}
+	sub := mockSubscriber{}
+	sub.On("AddTopicEventHandler", mock.Anything, mock.Anything).Return(nil)
+	pub.On("PublishEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	p, err := NewPandora(&pub, &sub, "", PandoraOpt{WaitTimeout: 100 * time.Millisecond})
+	assert.NoError(t, err)
+
+	_, err = p.Stop(context.Background(), "", "1")
+	assert.Error(t, err)
+	payload, _ := json.Marshal(StopPandoraReply{Ids: []string{"a"}})
+	done := make(chan bool)
+	go func() {
+		_, err := p.onStoppedReply(context.Background(), &common.TopicEvent{RawData: payload})
+		assert.NoError(t, err)
+		done <- true
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("late reply blocked the subscription")
+	}
+}
+
+// Pandora refused to start, the error is returned without waiting for the timeout
+func TestPandora_Start_Refused(t *testing.T) {
+	pub := mockPublisher{}
+	sub := mockSubscriber{}
+	sub.On("AddTopicEventHandler", mock.Anything, mock.Anything).Return(nil)
+	pub.On("PublishEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	p, err := NewPandora(&pub, &sub, "", PandoraOpt{})
+	assert.NoError(t, err)
+
+	payload, _ := json.Marshal(StartPandoraReply{
+		Envelope:       Envelope{Error: &Error{Code: MissingPermissions, Message: "cannot join channel 1"}},
+		VoiceChannelId: "1",
+	})
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		ok, err := p.onStartedReply(context.Background(), &common.TopicEvent{RawData: payload})
+		assert.False(t, ok)
+		assert.NoError(t, err)
+	}()
+	began := time.Now()
+	_, err = p.Start(context.Background(), "1")
+	assert.Less(t, time.Since(began), time.Second)
+	var pErr *Error
+	assert.ErrorAs(t, err, &pErr)
+	assert.Equal(t, MissingPermissions, pErr.Code)
+	assert.Contains(t, err.Error(), "cannot join channel 1")
+}
+
+func TestPandora_Stop_Timeout(t *testing.T) {
+	pub := mockPublisher{}
+	sub := mockSubscriber{}
+	sub.On("AddTopicEventHandler", mock.Anything, mock.Anything).Return(nil)
+	pub.On("PublishEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	p, err := NewPandora(&pub, &sub, "", PandoraOpt{WaitTimeout: 50 * time.Millisecond})
+	assert.NoError(t, err)
+
+	_, err = p.Stop(context.Background(), "", "1")
+	assert.ErrorIs(t, err, ErrTimeout)
+}
+
+// Pandora stopped on its own, sending a stop reply nobody asked for
+func TestPandora_UnsolicitedStop(t *testing.T) {
+	sub := mockSubscriber{}
+	sub.On("AddTopicEventHandler", mock.Anything, mock.Anything).Return(nil)
+	p, err := NewPandora(&mockPublisher{}, &sub, "", PandoraOpt{})
+	assert.NoError(t, err)
+	var interruptions []Interruption
+	p.OnInterrupted(func(ctx context.Context, i Interruption) error {
+		interruptions = append(interruptions, i)
+		return nil
+	})
+
+	payload, _ := json.Marshal(StopPandoraReply{VoiceChannelId: "1", Ids: []string{"a"}})
+	_, err = p.onStoppedReply(context.Background(), &common.TopicEvent{RawData: payload})
+	assert.NoError(t, err)
+	// A late reply to a request that timed out isn't an interruption
+	payload, _ = json.Marshal(StopPandoraReply{Envelope: Envelope{CorrelationId: "late"}, VoiceChannelId: "1"})
+	_, err = p.onStoppedReply(context.Background(), &common.TopicEvent{RawData: payload})
+	assert.NoError(t, err)
+	assert.Equal(t, []Interruption{{VoiceChannelId: "1", Reason: DEFAULT_STOP_REASON, Ids: []string{"a"}}}, interruptions)
+}
+
+func TestPandora_OnDisconnected(t *testing.T) {
+	sub := mockSubscriber{}
+	sub.On("AddTopicEventHandler", mock.Anything, mock.Anything).Return(nil)
+	p, err := NewPandora(&mockPublisher{}, &sub, "", PandoraOpt{})
+	assert.NoError(t, err)
+	var interruptions []Interruption
+	p.OnInterrupted(func(ctx context.Context, i Interruption) error {
+		interruptions = append(interruptions, i)
+		return nil
+	})
+
+	payload, _ := json.Marshal(DisconnectedPandoraEvent{VoiceChannelId: "1", Reason: "kicked", Ids: []string{"a"}})
+	_, err = p.onDisconnected(context.Background(), &common.TopicEvent{RawData: payload})
+	assert.NoError(t, err)
+	payload, _ = json.Marshal(DisconnectedPandoraEvent{VoiceChannelId: "2"})
+	_, err = p.onDisconnected(context.Background(), &common.TopicEvent{RawData: payload})
+	assert.NoError(t, err)
+	_, err = p.onDisconnected(context.Background(), &common.TopicEvent{RawData: []byte("wrong")})
+	assert.Error(t, err)
+	assert.Equal(t, []Interruption{
+		{VoiceChannelId: "1", Reason: "kicked", Ids: []string{"a"}},
+		{VoiceChannelId: "2", Reason: DEFAULT_DISCONNECT_REASON},
+	}, interruptions)
+}
+
+// Pandora stopped sending heartbeats, starting fails without asking it
+func TestPandora_Start_Down(t *testing.T) {
+	pub := mockPublisher{}
+	sub := mockSubscriber{}
+	sub.On("AddTopicEventHandler", mock.Anything, mock.Anything).Return(nil)
+	p, err := NewPandora(&pub, &sub, "", PandoraOpt{HeartbeatTimeout: 50 * time.Millisecond})
+	assert.NoError(t, err)
+
+	payload, _ := json.Marshal(HeartbeatPandoraEvent{InstanceId: "a", Version: "2.0.0"})
+	_, err = p.onHeartbeat(context.Background(), &common.TopicEvent{RawData: payload})
+	assert.NoError(t, err)
+	assert.Equal(t, LivenessUp, p.Liveness().Status)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, LivenessDown, p.Liveness().Status)
+
+	_, err = p.Start(context.Background(), "1")
+	assert.ErrorIs(t, err, ErrUnavailable)
+	pub.AssertNotCalled(t, "PublishEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPandora_OnHeartbeat_WrongEvent(t *testing.T) {
+	sub := mockSubscriber{}
+	sub.On("AddTopicEventHandler", mock.Anything, mock.Anything).Return(nil)
+	p, err := NewPandora(&mockPublisher{}, &sub, "", PandoraOpt{})
+	assert.NoError(t, err)
+
+	_, err = p.onHeartbeat(context.Background(), &common.TopicEvent{RawData: []byte("wrong")})
+	assert.Error(t, err)
+	assert.Equal(t, LivenessUnknown, p.Liveness().Status)
+}
+
+// Requests are sent to a free instance, and then to the instance recording the voice channel
+func TestPandora_RoutesToInstance(t *testing.T) {
+	pub := mockPublisher{}
+	sub := mockSubscriber{}
+	sub.On("AddTopicEventHandler", mock.Anything, mock.Anything).Return(nil)
+	p, err := NewPandora(&pub, &sub, "", PandoraOpt{})
+	assert.NoError(t, err)
+	for _, hb := range []HeartbeatPandoraEvent{{InstanceId: "a", VoiceChannelId: "9"}, {InstanceId: "b"}} {
+		payload, _ := json.Marshal(hb)
+		_, err = p.onHeartbeat(context.Background(), &common.TopicEvent{RawData: payload})
+		assert.NoError(t, err)
+	}
+	var started StartPandoraRequest
+	// Addressed requests are published on the topics of the instance
+	pub.On("PublishEvent", mock.Anything, mock.Anything, "startRecordingDiscord.b", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		started = args.Get(3).(StartPandoraRequest)
+		payload, _ := json.Marshal(StartPandoraReply{Envelope: Envelope{CorrelationId: started.CorrelationId, InstanceId: "b"}, VoiceChannelId: started.VoiceChannelId})
+		go p.onStartedReply(context.Background(), &common.TopicEvent{RawData: payload})
+	}).Return(nil)
+	pub.On("PublishEvent", mock.Anything, mock.Anything, "stopRecordingDiscord.b", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stop := args.Get(3).(StopPandoraRequest)
+		assert.Equal(t, "b", stop.InstanceId)
+		payload, _ := json.Marshal(StopPandoraReply{Envelope: Envelope{CorrelationId: stop.CorrelationId, InstanceId: "b"}, Ids: []string{"a"}})
+		go p.onStoppedReply(context.Background(), &common.TopicEvent{RawData: payload})
+	}).Return(nil)
+
+	instanceId, err := p.Start(context.Background(), "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "b", instanceId)
+	assert.Equal(t, "b", started.InstanceId)
+	_, err = p.Start(context.Background(), "2")
+	assert.ErrorIs(t, err, ErrNoInstance)
+
+	_, err = p.Stop(context.Background(), instanceId, "1")
+	assert.NoError(t, err)
+	// Stopped, b is free again
+	instanceId, err = p.Start(context.Background(), "2")
+	assert.NoError(t, err)
+	assert.Equal(t, "b", instanceId)
+}
+
+func TestNewPandora_Topics(t *testing.T) {
+	sub := mockSubscriber{}
+	var subscriptions []*common.Subscription
+	sub.On("AddTopicEventHandler", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		subscriptions = append(subscriptions, args.Get(0).(*common.Subscription))
+	}).Return(nil)
+	metadata := map[string]string{"rawPayload": "true"}
+	_, err := NewPandora(&mockPublisher{}, &sub, "pubsub", PandoraOpt{
+		Topics:   Topics{Start: "discord.start", Started: "discord.started"},
+		Metadata: metadata,
+	})
+	assert.NoError(t, err)
+
+	topics := map[string]bool{}
+	for _, s := range subscriptions {
+		assert.Equal(t, "pubsub", s.PubsubName)
+		assert.Equal(t, metadata, s.Metadata)
+		topics[s.Topic] = true
+	}
+	// Topics left empty keep their default
+	assert.Equal(t, map[string]bool{
+		"discord.started": true, S_Ended: true, S_Status: true, S_Paused: true,
+		S_Resumed: true, S_Disconnected: true, S_Heartbeat: true,
+	}, topics)
+}
+
+// A subscription only has one handler
+func TestNewPandora_DuplicateTopics(t *testing.T) {
+	sub := mockSubscriber{}
+	sub.On("AddTopicEventHandler", mock.Anything, mock.Anything).Return(nil)
+	_, err := NewPandora(&mockPublisher{}, &sub, "", PandoraOpt{Topics: Topics{Paused: "toggled", Resumed: "toggled"}})
+	assert.Error(t, err)
+	sub.AssertNotCalled(t, "AddTopicEventHandler", mock.Anything, mock.Anything)
+}
+
+// Requests carry the schema version, and are published on the configured topic with the configured metadata
+func TestPandora_PublishesRequests(t *testing.T) {
+	pub := mockPublisher{}
+	sub := mockSubscriber{}
+	sub.On("AddTopicEventHandler", mock.Anything, mock.Anything).Return(nil)
+	p, err := NewPandora(&pub, &sub, "", PandoraOpt{
+		WaitTimeout: 50 * time.Millisecond,
+		Topics:      Topics{Stop: "discord.stop"},
+		Metadata:    map[string]string{"rawPayload": "true"},
+	})
+	assert.NoError(t, err)
+	pub.On("PublishEvent", mock.Anything, mock.Anything, "discord.stop", mock.MatchedBy(func(r StopPandoraRequest) bool {
+		return r.SchemaVersion == SCHEMA_VERSION && r.IsRequest
+	}), mock.MatchedBy(func(opts []utils.PublishEventOption) bool {
+		return len(opts) == 1
+	})).Return(nil).Once()
+
+	_, err = p.Stop(context.Background(), "", "1")
+	assert.ErrorIs(t, err, ErrTimeout)
+	pub.AssertExpectations(t)
+}
+
+// Pandora speaks another version of the schema, its reply isn't trusted
+func TestPandora_Start_SchemaMismatch(t *testing.T) {
+	pub := mockPublisher{}
+	sub := mockSubscriber{}
+	sub.On("AddTopicEventHandler", mock.Anything, mock.Anything).Return(nil)
+	p, err := NewPandora(&pub, &sub, "", PandoraOpt{})
+	assert.NoError(t, err)
+	pub.On("PublishEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		request := args.Get(3).(StartPandoraRequest)
+		payload, _ := json.Marshal(StartPandoraReply{Envelope: Envelope{CorrelationId: request.CorrelationId, SchemaVersion: SCHEMA_VERSION + 1}, VoiceChannelId: "1"})
+		go p.onStartedReply(context.Background(), &common.TopicEvent{RawData: payload})
+	}).Return(nil)
+
+	began := time.Now()
+	_, err = p.Start(context.Background(), "1")
+	assert.ErrorIs(t, err, ErrSchemaMismatch)
+	assert.Less(t, time.Since(began), time.Second)
+}
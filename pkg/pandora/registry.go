@@ -0,0 +1,142 @@
+package pandora
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// registry keeps track of the Pandora instances, as told by their heartbeats,
+// and of the voice channels each one was asked to record
+type registry struct {
+	mu sync.Mutex
+	// An instance whose last heartbeat is older than this is considered down
+	timeout time.Duration
+	// Version of the message schema the instances must speak to be picked
+	schemaVersion int
+	// Instances seen within the timeout, by id
+	instances map[string]Instance
+	// Voice channels the instances were asked to record, by instance id.
+	// Heartbeats may not tell yet that an instance is busy
+	claims map[string]claim
+	// Last heartbeat of any instance, zero if none was ever received
+	lastSeen time.Time
+}
+
+// An instance picked to record a voice channel
+type claim struct {
+	vcId string
+	at   time.Time
+}
+
+func newRegistry(timeout time.Duration, schemaVersion int) *registry {
+	return &registry{timeout: timeout, schemaVersion: schemaVersion, instances: make(map[string]Instance), claims: make(map[string]claim)}
+}
+
+// Record a heartbeat received at, returning whether the instance is new or was upgraded
+func (r *registry) beat(hb HeartbeatPandoraEvent, at time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	known, ok := r.instances[hb.InstanceId]
+	r.instances[hb.InstanceId] = Instance{
+		Id:             hb.InstanceId,
+		Version:        hb.Version,
+		SchemaVersion:  hb.SchemaVersion,
+		VoiceChannelId: hb.VoiceChannelId,
+		LastSeen:       at,
+	}
+	if at.After(r.lastSeen) {
+		r.lastSeen = at
+	}
+	// The instance had all the time it needed to start recording, and didn't
+	if c, ok := r.claims[hb.InstanceId]; ok && c.vcId != hb.VoiceChannelId && at.Sub(c.at) > r.timeout {
+		delete(r.claims, hb.InstanceId)
+	}
+	return !ok || known.Version != hb.Version || known.SchemaVersion != hb.SchemaVersion
+}
+
+// Whether a message of the given schema version can be understood
+func (r *registry) compatible(schemaVersion int) bool {
+	return schemaVersion == 0 || schemaVersion == r.schemaVersion
+}
+
+// Liveness of Pandora at now. Instances that timed out are forgotten
+func (r *registry) liveness(now time.Time) Liveness {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return Liveness{Status: r.status(now), LastSeen: r.lastSeen, Instances: r.alive(now), SchemaVersion: r.schemaVersion}
+}
+
+// Pick the instance to record vcId at now, preferring the one already recording it, or else a free one.
+// Instances speaking another version of the message schema are never picked. Without heartbeats,
+// instances cannot be told apart and an empty id is returned, for any instance to record it
+func (r *registry) claim(vcId string, now time.Time) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.lastSeen.IsZero() {
+		return "", nil
+	}
+	var alive []Instance
+	for _, instance := range r.alive(now) {
+		if r.compatible(instance.SchemaVersion) {
+			alive = append(alive, instance)
+		}
+	}
+	if len(alive) == 0 {
+		return "", fmt.Errorf("%w, no instance speaks version %d", ErrSchemaMismatch, r.schemaVersion)
+	}
+	for _, instance := range alive {
+		if instance.VoiceChannelId == vcId || r.claims[instance.Id].vcId == vcId {
+			r.claims[instance.Id] = claim{vcId: vcId, at: now}
+			return instance.Id, nil
+		}
+	}
+	for _, instance := range alive {
+		if _, claimed := r.claims[instance.Id]; instance.VoiceChannelId == "" && !claimed {
+			r.claims[instance.Id] = claim{vcId: vcId, at: now}
+			return instance.Id, nil
+		}
+	}
+	return "", ErrNoInstance
+}
+
+// Release the instance that was picked to record vcId, if any
+func (r *registry) release(vcId string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, c := range r.claims {
+		if c.vcId == vcId {
+			delete(r.claims, id)
+		}
+	}
+}
+
+func (r *registry) status(now time.Time) LivenessStatus {
+	switch {
+	case r.lastSeen.IsZero():
+		return LivenessUnknown
+	case len(r.alive(now)) > 0:
+		return LivenessUp
+	default:
+		return LivenessDown
+	}
+}
+
+// Instances seen within the timeout, sorted by id. The others are forgotten,
+// along with the voice channels they were asked to record
+func (r *registry) alive(now time.Time) []Instance {
+	alive := make([]Instance, 0, len(r.instances))
+	for id, instance := range r.instances {
+		if now.Sub(instance.LastSeen) > r.timeout {
+			delete(r.instances, id)
+			delete(r.claims, id)
+			continue
+		}
+		alive = append(alive, instance)
+	}
+	sort.Slice(alive, func(i, j int) bool {
+		return alive[i].Id < alive[j].Id
+	})
+	return alive
+}
@@ -0,0 +1,109 @@
+package pandora
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRegistry_Liveness(t *testing.T) {
+	m := newRegistry(time.Minute, SCHEMA_VERSION)
+	now := time.Now()
+	// Pandora may not send heartbeats at all
+	assert.Equal(t, LivenessUnknown, m.liveness(now).Status)
+
+	m.beat(HeartbeatPandoraEvent{InstanceId: "b", Version: "2.0.0", VoiceChannelId: "1"}, now)
+	m.beat(HeartbeatPandoraEvent{InstanceId: "a", Version: "2.0.0"}, now.Add(-30*time.Second))
+	l := m.liveness(now)
+	assert.Equal(t, LivenessUp, l.Status)
+	assert.Equal(t, now, l.LastSeen)
+	assert.Equal(t, []Instance{
+		{Id: "a", Version: "2.0.0", LastSeen: now.Add(-30 * time.Second)},
+		{Id: "b", Version: "2.0.0", VoiceChannelId: "1", LastSeen: now},
+	}, l.Instances)
+
+	// Instances that timed out are forgotten
+	l = m.liveness(now.Add(45 * time.Second))
+	assert.Equal(t, LivenessUp, l.Status)
+	assert.Len(t, l.Instances, 1)
+	l = m.liveness(now.Add(2 * time.Minute))
+	assert.Equal(t, LivenessDown, l.Status)
+	assert.Equal(t, now, l.LastSeen)
+	assert.Empty(t, l.Instances)
+
+	// And come back with their next heartbeat
+	m.beat(HeartbeatPandoraEvent{InstanceId: "a"}, now.Add(3*time.Minute))
+	assert.Equal(t, LivenessUp, m.liveness(now.Add(3*time.Minute)).Status)
+}
+
+func TestRegistry_Claim(t *testing.T) {
+	r := newRegistry(time.Minute, SCHEMA_VERSION)
+	now := time.Now()
+	// Without heartbeats, any instance records
+	id, err := r.claim("1", now)
+	assert.NoError(t, err)
+	assert.Empty(t, id)
+
+	r.beat(HeartbeatPandoraEvent{InstanceId: "a", VoiceChannelId: "9"}, now)
+	r.beat(HeartbeatPandoraEvent{InstanceId: "b"}, now)
+	r.beat(HeartbeatPandoraEvent{InstanceId: "c"}, now)
+	id, err = r.claim("1", now)
+	assert.NoError(t, err)
+	assert.Equal(t, "b", id)
+	// Heartbeats don't tell yet that b is busy
+	id, err = r.claim("2", now)
+	assert.NoError(t, err)
+	assert.Equal(t, "c", id)
+	_, err = r.claim("3", now)
+	assert.ErrorIs(t, err, ErrNoInstance)
+	// The instance already recording a voice channel is picked again
+	id, err = r.claim("9", now)
+	assert.NoError(t, err)
+	assert.Equal(t, "a", id)
+	id, err = r.claim("1", now)
+	assert.NoError(t, err)
+	assert.Equal(t, "b", id)
+
+	r.release("2")
+	id, err = r.claim("3", now)
+	assert.NoError(t, err)
+	assert.Equal(t, "c", id)
+}
+
+// An instance that never started recording the voice channel it was picked for is free again
+func TestRegistry_StaleClaim(t *testing.T) {
+	r := newRegistry(time.Minute, SCHEMA_VERSION)
+	now := time.Now()
+	r.beat(HeartbeatPandoraEvent{InstanceId: "a"}, now)
+	id, _ := r.claim("1", now)
+	assert.Equal(t, "a", id)
+
+	r.beat(HeartbeatPandoraEvent{InstanceId: "a"}, now.Add(30*time.Second))
+	_, err := r.claim("2", now.Add(30*time.Second))
+	assert.ErrorIs(t, err, ErrNoInstance)
+	r.beat(HeartbeatPandoraEvent{InstanceId: "a"}, now.Add(2*time.Minute))
+	id, err = r.claim("2", now.Add(2*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, "a", id)
+}
+
+// Instances speaking another version of the message schema are never picked
+func TestRegistry_ClaimSchemaVersion(t *testing.T) {
+	r := newRegistry(time.Minute, 2)
+	now := time.Now()
+	r.beat(HeartbeatPandoraEvent{InstanceId: "a", SchemaVersion: 1}, now)
+	_, err := r.claim("1", now)
+	assert.ErrorIs(t, err, ErrSchemaMismatch)
+
+	// Without a version, the instance predates the versioning of the schema
+	r.beat(HeartbeatPandoraEvent{InstanceId: "b"}, now)
+	r.beat(HeartbeatPandoraEvent{InstanceId: "c", SchemaVersion: 2}, now)
+	id, err := r.claim("1", now)
+	assert.NoError(t, err)
+	assert.Equal(t, "b", id)
+	id, err = r.claim("2", now)
+	assert.NoError(t, err)
+	assert.Equal(t, "c", id)
+	_, err = r.claim("3", now)
+	assert.ErrorIs(t, err, ErrNoInstance)
+}
@@ -1,6 +1,12 @@
 package roll20_sync
 
+import "context"
+
 type R20Recorder interface {
-	Start(r20Id string) error
-	Stop(r20Id string) (string, error)
+	Start(ctx context.Context, r20Id string) error
+	Stop(ctx context.Context, r20Id string) (string, error)
+	// IsRecording probes the syncer to know whether r20Id is being recorded
+	IsRecording(ctx context.Context, r20Id string) (bool, error)
+	Pause(ctx context.Context, r20Id string) error
+	Resume(ctx context.Context, r20Id string) error
 }
@@ -16,6 +16,10 @@ type payload struct {
 	Id string `json:"id"`
 }
 
+type statusReply struct {
+	Recording bool `json:"recording"`
+}
+
 func NewRoll20Sync(client utils.Invoker, component string) *Roll20Sync {
 	return &Roll20Sync{
 		client:    client,
@@ -23,7 +27,7 @@ func NewRoll20Sync(client utils.Invoker, component string) *Roll20Sync {
 	}
 }
 
-func (r *Roll20Sync) Start(r20Id string) error {
+func (r *Roll20Sync) Start(ctx context.Context, r20Id string) error {
 
 	content, err := json.Marshal(payload{
 		Id: r20Id,
@@ -31,7 +35,7 @@ func (r *Roll20Sync) Start(r20Id string) error {
 	if err != nil {
 		return err
 	}
-	res, err := r.client.InvokeMethodWithContent(context.Background(), r.component, "v1/jukeboxsyncer/start", "POST", &utils.DataContent{
+	res, err := r.client.InvokeMethodWithContent(ctx, r.component, "v1/jukeboxsyncer/start", "POST", &utils.DataContent{
 		Data:        content,
 		ContentType: "application/json",
 	})
@@ -39,7 +43,7 @@ func (r *Roll20Sync) Start(r20Id string) error {
 	return err
 }
 
-func (r *Roll20Sync) Stop(r20Id string) (string, error) {
+func (r *Roll20Sync) Stop(ctx context.Context, r20Id string) (string, error) {
 
 	content, err := json.Marshal(payload{
 		Id: r20Id,
@@ -47,7 +51,7 @@ func (r *Roll20Sync) Stop(r20Id string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	_, err = r.client.InvokeMethodWithContent(context.Background(), r.component, "v1/jukeboxsyncer/stop", "POST", &utils.DataContent{
+	_, err = r.client.InvokeMethodWithContent(ctx, r.component, "v1/jukeboxsyncer/stop", "POST", &utils.DataContent{
 		Data:        content,
 		ContentType: "application/json",
 	})
@@ -56,3 +60,49 @@ func (r *Roll20Sync) Stop(r20Id string) (string, error) {
 	}
 	return fmt.Sprintf("%s.ogg", r20Id), nil
 }
+
+func (r *Roll20Sync) IsRecording(ctx context.Context, r20Id string) (bool, error) {
+
+	content, err := json.Marshal(payload{
+		Id: r20Id,
+	})
+	if err != nil {
+		return false, err
+	}
+	res, err := r.client.InvokeMethodWithContent(ctx, r.component, "v1/jukeboxsyncer/status", "POST", &utils.DataContent{
+		Data:        content,
+		ContentType: "application/json",
+	})
+	if err != nil {
+		return false, err
+	}
+	status := statusReply{}
+	err = json.Unmarshal(res, &status)
+	if err != nil {
+		return false, fmt.Errorf("[Roll20Sync] :: Received wrong status from the syncer %s, %w", res, err)
+	}
+	return status.Recording, nil
+}
+
+func (r *Roll20Sync) Pause(ctx context.Context, r20Id string) error {
+	return r.invoke(ctx, "v1/jukeboxsyncer/pause", r20Id)
+}
+
+func (r *Roll20Sync) Resume(ctx context.Context, r20Id string) error {
+	return r.invoke(ctx, "v1/jukeboxsyncer/resume", r20Id)
+}
+
+// Invoke a method of the syncer taking a game id and returning nothing
+func (r *Roll20Sync) invoke(ctx context.Context, method string, r20Id string) error {
+	content, err := json.Marshal(payload{
+		Id: r20Id,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = r.client.InvokeMethodWithContent(ctx, r.component, method, "POST", &utils.DataContent{
+		Data:        content,
+		ContentType: "application/json",
+	})
+	return err
+}
@@ -0,0 +1,53 @@
+package webhooks
+
+import (
+	"context"
+	"record-orchestrator/pkg/events"
+	"time"
+)
+
+// Webhook is an HTTP endpoint notified of the lifecycle events of the sessions
+type Webhook struct {
+	Id  string `json:"id"`
+	Url string `json:"url"`
+	// Key signing the bodies sent to the webhook
+	Secret string `json:"secret"`
+	// Kinds of events sent to the webhook, every kind if empty
+	Events []events.Kind `json:"events,omitempty"`
+}
+
+type DeliveryStatus string
+
+const (
+	// Not delivered yet, but will be retried
+	Pending   DeliveryStatus = "pending"
+	Delivered DeliveryStatus = "delivered"
+	// Every attempt failed
+	Failed DeliveryStatus = "failed"
+)
+
+// Delivery is an event sent to a webhook, kept in the delivery log
+type Delivery struct {
+	Id        string
+	WebhookId string
+	Kind      events.Kind
+	SessionId string `json:",omitempty"`
+	// Signed body of the request
+	Payload  []byte
+	Status   DeliveryStatus
+	Attempts int
+	// Status code of the last attempt, 0 if it didn't get a response
+	StatusCode int    `json:",omitempty"`
+	Error      string `json:",omitempty"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// DeliveryLog persists every delivery
+type DeliveryLog interface {
+	Save(ctx context.Context, key string, value Delivery) error
+	Get(ctx context.Context, key string) (*Delivery, error)
+	Delete(ctx context.Context, key string) error
+	// Keys of every delivery
+	Keys(ctx context.Context) ([]string, error)
+}
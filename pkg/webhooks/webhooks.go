@@ -0,0 +1,254 @@
+// Lifecycle events are sent to HTTP webhooks, for the consumers that cannot subscribe to the pubsub component
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"record-orchestrator/pkg/events"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	DEFAULT_MAX_ATTEMPTS = 5
+	// Delay before the first retry, doubled after each attempt
+	DEFAULT_BACKOFF = time.Second
+	DEFAULT_TIMEOUT = 10 * time.Second
+	// Ended deliveries are pruned from the log once they are older than that
+	DEFAULT_RETENTION = 7 * 24 * time.Hour
+	// Longest wait for the delivery log to save a delivery
+	LOG_TIMEOUT = 5 * time.Second
+	// Headers of the requests sent to the webhooks
+	SIGNATURE_HEADER = "X-Recorder-Signature"
+	TIMESTAMP_HEADER = "X-Recorder-Timestamp"
+	EVENT_HEADER     = "X-Recorder-Event"
+	DELIVERY_HEADER  = "X-Recorder-Delivery"
+)
+
+type DispatcherOpt struct {
+	// Client sending the requests, with a DEFAULT_TIMEOUT if nil
+	Client *http.Client
+	// Attempts before giving up on a delivery, DEFAULT_MAX_ATTEMPTS if 0
+	MaxAttempts int
+	// Delay before the first retry, DEFAULT_BACKOFF if 0
+	Backoff time.Duration
+	// How long ended deliveries are kept in the log, DEFAULT_RETENTION if 0
+	Retention time.Duration
+}
+
+// Dispatcher sends the lifecycle events to the webhooks interested in them.
+// Deliveries are made in the background, so that a slow webhook never slows down the recordings
+type Dispatcher struct {
+	webhooks    map[string]Webhook
+	log         DeliveryLog
+	client      *http.Client
+	maxAttempts int
+	backoff     time.Duration
+	retention   time.Duration
+}
+
+func NewDispatcher(webhooks []Webhook, log DeliveryLog, opt DispatcherOpt) *Dispatcher {
+	if opt.Client == nil {
+		opt.Client = &http.Client{Timeout: DEFAULT_TIMEOUT}
+	}
+	if opt.MaxAttempts <= 0 {
+		opt.MaxAttempts = DEFAULT_MAX_ATTEMPTS
+	}
+	if opt.Backoff <= 0 {
+		opt.Backoff = DEFAULT_BACKOFF
+	}
+	if opt.Retention <= 0 {
+		opt.Retention = DEFAULT_RETENTION
+	}
+	d := &Dispatcher{
+		webhooks:    make(map[string]Webhook),
+		log:         log,
+		client:      opt.Client,
+		maxAttempts: opt.MaxAttempts,
+		backoff:     opt.Backoff,
+		retention:   opt.Retention,
+	}
+	for _, webhook := range webhooks {
+		d.webhooks[webhook.Id] = webhook
+	}
+	return d
+}
+
+// LoadWebhooks reads the webhooks from a JSON file, holding an array of webhooks
+func LoadWebhooks(path string) ([]Webhook, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var webhooks []Webhook
+	if err = json.Unmarshal(content, &webhooks); err != nil {
+		return nil, fmt.Errorf("[Webhooks] :: Invalid webhooks file %s : %w", path, err)
+	}
+	ids := make(map[string]bool)
+	for _, webhook := range webhooks {
+		if webhook.Id == "" || webhook.Url == "" || webhook.Secret == "" {
+			return nil, fmt.Errorf("[Webhooks] :: id, url and secret are required but got webhook %s", webhook.Id)
+		}
+		if ids[webhook.Id] {
+			return nil, fmt.Errorf("[Webhooks] :: webhook id %s is used twice", webhook.Id)
+		}
+		ids[webhook.Id] = true
+	}
+	return webhooks, nil
+}
+
+// Sign a body sent at timestamp, in unix seconds, with the secret of a webhook, as sent in the SIGNATURE_HEADER.
+// The timestamp is signed along with the body, so that a receiver can reject replayed requests
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Emit queues the delivery of an event to every webhook interested in it
+func (d *Dispatcher) Emit(e events.Event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	for _, webhook := range d.webhooks {
+		if len(webhook.Events) > 0 && !slices.Contains(webhook.Events, e.Kind) {
+			continue
+		}
+		now := time.Now()
+		delivery := Delivery{
+			Id:        uuid.NewString(),
+			WebhookId: webhook.Id,
+			Kind:      e.Kind,
+			SessionId: e.SessionId,
+			Payload:   payload,
+			Status:    Pending,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		go func() {
+			// Logged before the first attempt, so that it can be resumed after a restart
+			d.save(delivery)
+			d.deliver(delivery)
+		}()
+	}
+	return nil
+}
+
+// Load resumes the deliveries still pending in the log, interrupted by a restart
+func (d *Dispatcher) Load(ctx context.Context) error {
+	keys, err := d.log.Keys(ctx)
+	if err != nil {
+		return err
+	}
+	resumed := 0
+	for _, key := range keys {
+		delivery, err := d.log.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+		if delivery == nil || delivery.Status != Pending {
+			continue
+		}
+		resumed++
+		go d.deliver(*delivery)
+	}
+	slog.Info(fmt.Sprintf("[Webhooks] :: Resumed %d pending deliveries", resumed))
+	return nil
+}
+
+// Prune removes the ended deliveries older than the retention from the log
+func (d *Dispatcher) Prune(ctx context.Context) error {
+	keys, err := d.log.Keys(ctx)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, key := range keys {
+		delivery, err := d.log.Get(ctx, key)
+		if err == nil && (delivery == nil || delivery.Status != Pending && time.Since(delivery.UpdatedAt) > d.retention) {
+			err = d.log.Delete(ctx, key)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Attempt a delivery until it succeeds or runs out of attempts,
+// waiting twice as long after each failed attempt
+func (d *Dispatcher) deliver(delivery Delivery) {
+	webhook, ok := d.webhooks[delivery.WebhookId]
+	if !ok {
+		delivery.Status, delivery.Error = Failed, "webhook isn't configured anymore"
+		d.save(delivery)
+		return
+	}
+	for delivery.Status == Pending {
+		if delivery.Attempts > 0 {
+			time.Sleep(d.backoff << (delivery.Attempts - 1))
+		}
+		delivery.Attempts++
+		retry := false
+		delivery.StatusCode, retry, delivery.Error = d.send(webhook, delivery)
+		switch {
+		case delivery.Error == "":
+			delivery.Status = Delivered
+		case !retry || delivery.Attempts >= d.maxAttempts:
+			delivery.Status = Failed
+			slog.Warn(fmt.Sprintf("[Webhooks] :: Giving up delivery %s of %s to %s after %d attempts : %s", delivery.Id, delivery.Kind, webhook.Id, delivery.Attempts, delivery.Error))
+		}
+		d.save(delivery)
+	}
+}
+
+// Send a delivery once, returning the status code, whether it's worth retrying, and what went wrong if it failed
+func (d *Dispatcher) send(webhook Webhook, delivery Delivery) (int, bool, string) {
+	req, err := http.NewRequest(http.MethodPost, webhook.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, false, err.Error()
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TIMESTAMP_HEADER, timestamp)
+	req.Header.Set(SIGNATURE_HEADER, Sign(webhook.Secret, timestamp, delivery.Payload))
+	req.Header.Set(EVENT_HEADER, string(delivery.Kind))
+	req.Header.Set(DELIVERY_HEADER, delivery.Id)
+	res, err := d.client.Do(req)
+	if err != nil {
+		return 0, true, err.Error()
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return res.StatusCode, false, ""
+	}
+	// Other client errors won't go away by themselves
+	retry := res.StatusCode >= 500 || res.StatusCode == http.StatusRequestTimeout || res.StatusCode == http.StatusTooManyRequests
+	return res.StatusCode, retry, fmt.Sprintf("webhook replied %s", res.Status)
+}
+
+// Best effort update of a delivery in the log.
+// Deliveries outlive the calls emitting their events, so the log gets its own deadline
+func (d *Dispatcher) save(delivery Delivery) {
+	delivery.UpdatedAt = time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), LOG_TIMEOUT)
+	defer cancel()
+	if err := d.log.Save(ctx, delivery.Id, delivery); err != nil {
+		slog.Warn(fmt.Sprintf("[Webhooks] :: Could not log delivery %s : %s", delivery.Id, err.Error()))
+	}
+}
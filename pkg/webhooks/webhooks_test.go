@@ -0,0 +1,238 @@
+package webhooks
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"record-orchestrator/pkg/events"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Delivery log kept in memory
+type memLog struct {
+	mu         sync.Mutex
+	deliveries map[string]Delivery
+}
+
+func newMemLog() *memLog {
+	return &memLog{deliveries: make(map[string]Delivery)}
+}
+
+func (l *memLog) Save(ctx context.Context, key string, value Delivery) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.deliveries[key] = value
+	return nil
+}
+
+func (l *memLog) Get(ctx context.Context, key string) (*Delivery, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if d, ok := l.deliveries[key]; ok {
+		return &d, nil
+	}
+	return nil, nil
+}
+
+func (l *memLog) Delete(ctx context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.deliveries, key)
+	return nil
+}
+
+func (l *memLog) Keys(ctx context.Context) ([]string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	keys := make([]string, 0, len(l.deliveries))
+	for k := range l.deliveries {
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+// Wait until the log holds deliveries, all of them ended, returning them
+func (l *memLog) settled(t *testing.T) []Delivery {
+	var deliveries []Delivery
+	assert.Eventually(t, func() bool {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		deliveries = deliveries[:0]
+		for _, d := range l.deliveries {
+			if d.Status == Pending {
+				return false
+			}
+			deliveries = append(deliveries, d)
+		}
+		return len(deliveries) > 0
+	}, time.Second, time.Millisecond)
+	return deliveries
+}
+
+func TestDispatcher_SignedDelivery(t *testing.T) {
+	var received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, err := strconv.ParseInt(r.Header.Get(TIMESTAMP_HEADER), 10, 64)
+		assert.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), time.Unix(timestamp, 0), time.Minute)
+		assert.Equal(t, Sign("secret", r.Header.Get(TIMESTAMP_HEADER), body), r.Header.Get(SIGNATURE_HEADER))
+		assert.Equal(t, string(events.Stopped), r.Header.Get(EVENT_HEADER))
+		assert.NotEmpty(t, r.Header.Get(DELIVERY_HEADER))
+		assert.Contains(t, string(body), `"sessionId":"s"`)
+		received.Add(1)
+	}))
+	defer server.Close()
+	log := newMemLog()
+	d := NewDispatcher([]Webhook{
+		{Id: "all", Url: server.URL, Secret: "secret"},
+		{Id: "filtered", Url: server.URL, Secret: "secret", Events: []events.Kind{events.Started}},
+	}, log, DispatcherOpt{})
+
+	assert.NoError(t, d.Emit(events.Event{Kind: events.Stopped, SessionId: "s"}))
+	deliveries := log.settled(t)
+	// The filtered webhook isn't interested in this event
+	assert.Len(t, deliveries, 1)
+	assert.Equal(t, "all", deliveries[0].WebhookId)
+	assert.Equal(t, Delivered, deliveries[0].Status)
+	assert.Equal(t, http.StatusOK, deliveries[0].StatusCode)
+	assert.Equal(t, int32(1), received.Load())
+}
+
+func TestDispatcher_Retries(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	log := newMemLog()
+	d := NewDispatcher([]Webhook{{Id: "w", Url: server.URL, Secret: "secret"}}, log, DispatcherOpt{Backoff: time.Millisecond})
+
+	assert.NoError(t, d.Emit(events.Event{Kind: events.Started}))
+	deliveries := log.settled(t)
+	assert.Equal(t, Delivered, deliveries[0].Status)
+	assert.Equal(t, 3, deliveries[0].Attempts)
+}
+
+func TestDispatcher_GivesUp(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	log := newMemLog()
+	d := NewDispatcher([]Webhook{{Id: "w", Url: server.URL, Secret: "secret"}}, log, DispatcherOpt{Backoff: time.Millisecond, MaxAttempts: 3})
+
+	assert.NoError(t, d.Emit(events.Event{Kind: events.Started}))
+	deliveries := log.settled(t)
+	assert.Equal(t, Failed, deliveries[0].Status)
+	assert.Equal(t, 3, deliveries[0].Attempts)
+	assert.Equal(t, http.StatusInternalServerError, deliveries[0].StatusCode)
+	assert.Equal(t, int32(3), attempts.Load())
+}
+
+func TestDispatcher_NoRetryOnClientError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	log := newMemLog()
+	d := NewDispatcher([]Webhook{{Id: "w", Url: server.URL, Secret: "secret"}}, log, DispatcherOpt{Backoff: time.Millisecond})
+
+	assert.NoError(t, d.Emit(events.Event{Kind: events.Started}))
+	deliveries := log.settled(t)
+	assert.Equal(t, Failed, deliveries[0].Status)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+}
+
+func TestDispatcher_LoadResumesPending(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	log := newMemLog()
+	_ = log.Save(ctx, "pending", Delivery{Id: "pending", WebhookId: "w", Status: Pending, Attempts: 1, Payload: []byte(`{}`)})
+	_ = log.Save(ctx, "failed", Delivery{Id: "failed", WebhookId: "w", Status: Failed, Attempts: 5})
+	_ = log.Save(ctx, "unknown", Delivery{Id: "unknown", WebhookId: "removed", Status: Pending})
+	d := NewDispatcher([]Webhook{{Id: "w", Url: server.URL, Secret: "secret"}}, log, DispatcherOpt{Backoff: time.Millisecond})
+
+	assert.NoError(t, d.Load(ctx))
+	log.settled(t)
+	pending, _ := log.Get(ctx, "pending")
+	assert.Equal(t, Delivered, pending.Status)
+	assert.Equal(t, 2, pending.Attempts)
+	failed, _ := log.Get(ctx, "failed")
+	assert.Equal(t, 5, failed.Attempts)
+	unknown, _ := log.Get(ctx, "unknown")
+	assert.Equal(t, Failed, unknown.Status)
+}
+
+// The log never slows down the emitter, even when it hangs
+func TestDispatcher_EmitDoesNotWaitForLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	log := &hangingLog{memLog: newMemLog(), release: make(chan struct{})}
+	defer close(log.release)
+	d := NewDispatcher([]Webhook{{Id: "w", Url: server.URL, Secret: "secret"}}, log, DispatcherOpt{})
+
+	done := make(chan error)
+	go func() { done <- d.Emit(events.Event{Kind: events.Started}) }()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Emit waited for the delivery log")
+	}
+}
+
+// Delivery log whose saves hang until released
+type hangingLog struct {
+	*memLog
+	release chan struct{}
+}
+
+func (l *hangingLog) Save(ctx context.Context, key string, value Delivery) error {
+	select {
+	case <-l.release:
+	case <-ctx.Done():
+	}
+	return l.memLog.Save(ctx, key, value)
+}
+
+func TestDispatcher_Prune(t *testing.T) {
+	ctx := context.Background()
+	log := newMemLog()
+	old := time.Now().Add(-2 * time.Hour)
+	_ = log.Save(ctx, "old", Delivery{Id: "old", Status: Delivered, UpdatedAt: old})
+	_ = log.Save(ctx, "oldFailed", Delivery{Id: "oldFailed", Status: Failed, UpdatedAt: old})
+	_ = log.Save(ctx, "recent", Delivery{Id: "recent", Status: Delivered, UpdatedAt: time.Now()})
+	// Pending deliveries are never pruned, they will be resumed
+	_ = log.Save(ctx, "pending", Delivery{Id: "pending", Status: Pending, UpdatedAt: old})
+	d := NewDispatcher(nil, log, DispatcherOpt{Retention: time.Hour})
+
+	assert.NoError(t, d.Prune(ctx))
+	keys, _ := log.Keys(ctx)
+	assert.ElementsMatch(t, []string{"recent", "pending"}, keys)
+}
+
+func TestLoadWebhooks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "webhooks.json")
+	_ = os.WriteFile(path, []byte(`[{"id": "w", "url": "http://localhost", "secret": "s", "events": ["recordingStopped"]}]`), 0600)
+	webhooks, err := LoadWebhooks(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []Webhook{{Id: "w", Url: "http://localhost", Secret: "s", Events: []events.Kind{events.Stopped}}}, webhooks)
+
+	_ = os.WriteFile(path, []byte(`[{"id": "w", "url": "http://localhost"}]`), 0600)
+	_, err = LoadWebhooks(path)
+	assert.Error(t, err)
+}
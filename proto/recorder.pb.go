@@ -20,6 +20,78 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Free-form description of a recording, for the tools processing it afterwards
+type RecordingMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Title         string            `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Campaign      string            `protobuf:"bytes,2,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	SessionNumber int32             `protobuf:"varint,3,opt,name=sessionNumber,proto3" json:"sessionNumber,omitempty"`
+	Labels        map[string]string `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *RecordingMetadata) Reset() {
+	*x = RecordingMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_recorder_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordingMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordingMetadata) ProtoMessage() {}
+
+func (x *RecordingMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recorder_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordingMetadata.ProtoReflect.Descriptor instead.
+func (*RecordingMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_recorder_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RecordingMetadata) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *RecordingMetadata) GetCampaign() string {
+	if x != nil {
+		return x.Campaign
+	}
+	return ""
+}
+
+func (x *RecordingMetadata) GetSessionNumber() int32 {
+	if x != nil {
+		return x.SessionNumber
+	}
+	return 0
+}
+
+func (x *RecordingMetadata) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 // Event message definition.
 type StartRecordRequest struct {
 	state         protoimpl.MessageState
@@ -28,12 +100,22 @@ type StartRecordRequest struct {
 
 	VoiceChannelId string `protobuf:"bytes,1,opt,name=voiceChannelId,proto3" json:"voiceChannelId,omitempty"`
 	Roll20GameId   string `protobuf:"bytes,2,opt,name=roll20GameId,proto3" json:"roll20GameId,omitempty"`
+	// Who asked for the recording, kept in the recordings history
+	Requester string `protobuf:"bytes,3,opt,name=requester,proto3" json:"requester,omitempty"`
+	// Stop the recording automatically after this long, in milliseconds.
+	// The server default applies if 0
+	MaxDurationMs int64              `protobuf:"varint,4,opt,name=maxDurationMs,proto3" json:"maxDurationMs,omitempty"`
+	Metadata      *RecordingMetadata `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Mix the tracks together once the recording is stopped
+	Mix bool `protobuf:"varint,6,opt,name=mix,proto3" json:"mix,omitempty"`
+	// Optional. A retried call with the same key gets the reply of the original call
+	IdempotencyKey string `protobuf:"bytes,7,opt,name=idempotencyKey,proto3" json:"idempotencyKey,omitempty"`
 }
 
 func (x *StartRecordRequest) Reset() {
 	*x = StartRecordRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_recorder_proto_msgTypes[0]
+		mi := &file_proto_recorder_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -46,7 +128,7 @@ func (x *StartRecordRequest) String() string {
 func (*StartRecordRequest) ProtoMessage() {}
 
 func (x *StartRecordRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_recorder_proto_msgTypes[0]
+	mi := &file_proto_recorder_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -59,7 +141,7 @@ func (x *StartRecordRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartRecordRequest.ProtoReflect.Descriptor instead.
 func (*StartRecordRequest) Descriptor() ([]byte, []int) {
-	return file_proto_recorder_proto_rawDescGZIP(), []int{0}
+	return file_proto_recorder_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *StartRecordRequest) GetVoiceChannelId() string {
@@ -76,6 +158,41 @@ func (x *StartRecordRequest) GetRoll20GameId() string {
 	return ""
 }
 
+func (x *StartRecordRequest) GetRequester() string {
+	if x != nil {
+		return x.Requester
+	}
+	return ""
+}
+
+func (x *StartRecordRequest) GetMaxDurationMs() int64 {
+	if x != nil {
+		return x.MaxDurationMs
+	}
+	return 0
+}
+
+func (x *StartRecordRequest) GetMetadata() *RecordingMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *StartRecordRequest) GetMix() bool {
+	if x != nil {
+		return x.Mix
+	}
+	return false
+}
+
+func (x *StartRecordRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 type StartRecordReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -83,12 +200,14 @@ type StartRecordReply struct {
 
 	Discord bool `protobuf:"varint,1,opt,name=discord,proto3" json:"discord,omitempty"`
 	Roll20  bool `protobuf:"varint,2,opt,name=roll20,proto3" json:"roll20,omitempty"`
+	// Identifies the session in the other calls
+	SessionId string `protobuf:"bytes,3,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
 }
 
 func (x *StartRecordReply) Reset() {
 	*x = StartRecordReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_recorder_proto_msgTypes[1]
+		mi := &file_proto_recorder_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -101,7 +220,7 @@ func (x *StartRecordReply) String() string {
 func (*StartRecordReply) ProtoMessage() {}
 
 func (x *StartRecordReply) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_recorder_proto_msgTypes[1]
+	mi := &file_proto_recorder_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -114,7 +233,7 @@ func (x *StartRecordReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StartRecordReply.ProtoReflect.Descriptor instead.
 func (*StartRecordReply) Descriptor() ([]byte, []int) {
-	return file_proto_recorder_proto_rawDescGZIP(), []int{1}
+	return file_proto_recorder_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *StartRecordReply) GetDiscord() bool {
@@ -131,6 +250,15 @@ func (x *StartRecordReply) GetRoll20() bool {
 	return false
 }
 
+func (x *StartRecordReply) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+// The session is either identified by its id, or by the same
+// voice channel and Roll20 game it was started with
 type StopRecordRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -138,12 +266,17 @@ type StopRecordRequest struct {
 
 	VoiceChannelId string `protobuf:"bytes,1,opt,name=voiceChannelId,proto3" json:"voiceChannelId,omitempty"`
 	Roll20GameId   string `protobuf:"bytes,2,opt,name=roll20GameId,proto3" json:"roll20GameId,omitempty"`
+	SessionId      string `protobuf:"bytes,3,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
+	// Mix the tracks together, even if it wasn't requested on start
+	Mix bool `protobuf:"varint,4,opt,name=mix,proto3" json:"mix,omitempty"`
+	// Optional. A retried call with the same key gets the reply of the original call
+	IdempotencyKey string `protobuf:"bytes,5,opt,name=idempotencyKey,proto3" json:"idempotencyKey,omitempty"`
 }
 
 func (x *StopRecordRequest) Reset() {
 	*x = StopRecordRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_recorder_proto_msgTypes[2]
+		mi := &file_proto_recorder_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -156,7 +289,7 @@ func (x *StopRecordRequest) String() string {
 func (*StopRecordRequest) ProtoMessage() {}
 
 func (x *StopRecordRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_recorder_proto_msgTypes[2]
+	mi := &file_proto_recorder_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -169,7 +302,7 @@ func (x *StopRecordRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopRecordRequest.ProtoReflect.Descriptor instead.
 func (*StopRecordRequest) Descriptor() ([]byte, []int) {
-	return file_proto_recorder_proto_rawDescGZIP(), []int{2}
+	return file_proto_recorder_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *StopRecordRequest) GetVoiceChannelId() string {
@@ -186,6 +319,27 @@ func (x *StopRecordRequest) GetRoll20GameId() string {
 	return ""
 }
 
+func (x *StopRecordRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *StopRecordRequest) GetMix() bool {
+	if x != nil {
+		return x.Mix
+	}
+	return false
+}
+
+func (x *StopRecordRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 type StopRecordReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -193,12 +347,19 @@ type StopRecordReply struct {
 
 	DiscordKeys []string `protobuf:"bytes,1,rep,name=discordKeys,proto3" json:"discordKeys,omitempty"`
 	Roll20Key   string   `protobuf:"bytes,2,opt,name=roll20Key,proto3" json:"roll20Key,omitempty"`
+	// Offset of each track (by key) from the start of the earliest one, in milliseconds
+	Offsets map[string]int64 `protobuf:"bytes,3,rep,name=offsets,proto3" json:"offsets,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Every time the recording was paused
+	Pauses   []*PauseInterval   `protobuf:"bytes,4,rep,name=pauses,proto3" json:"pauses,omitempty"`
+	Metadata *RecordingMetadata `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// The job mixing the tracks, if requested
+	Mix *MixJob `protobuf:"bytes,6,opt,name=mix,proto3" json:"mix,omitempty"`
 }
 
 func (x *StopRecordReply) Reset() {
 	*x = StopRecordReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_recorder_proto_msgTypes[3]
+		mi := &file_proto_recorder_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -211,7 +372,7 @@ func (x *StopRecordReply) String() string {
 func (*StopRecordReply) ProtoMessage() {}
 
 func (x *StopRecordReply) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_recorder_proto_msgTypes[3]
+	mi := &file_proto_recorder_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -224,7 +385,7 @@ func (x *StopRecordReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopRecordReply.ProtoReflect.Descriptor instead.
 func (*StopRecordReply) Descriptor() ([]byte, []int) {
-	return file_proto_recorder_proto_rawDescGZIP(), []int{3}
+	return file_proto_recorder_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *StopRecordReply) GetDiscordKeys() []string {
@@ -241,120 +402,2450 @@ func (x *StopRecordReply) GetRoll20Key() string {
 	return ""
 }
 
-var File_proto_recorder_proto protoreflect.FileDescriptor
-
-var file_proto_recorder_proto_rawDesc = []byte{
-	0x0a, 0x14, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72,
-	0x22, 0x60, 0x0a, 0x12, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x43,
-	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
-	0x76, 0x6f, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x22,
-	0x0a, 0x0c, 0x72, 0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x47, 0x61, 0x6d, 0x65,
-	0x49, 0x64, 0x22, 0x44, 0x0a, 0x10, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72,
-	0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x72,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x64,
-	0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x06, 0x72, 0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x22, 0x5f, 0x0a, 0x11, 0x53, 0x74, 0x6f, 0x70,
-	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a,
-	0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e,
-	0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x47,
-	0x61, 0x6d, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x6f, 0x6c,
-	0x6c, 0x32, 0x30, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x22, 0x51, 0x0a, 0x0f, 0x53, 0x74, 0x6f,
-	0x70, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x20, 0x0a, 0x0b,
-	0x64, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x64, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x64, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x1c,
-	0x0a, 0x09, 0x72, 0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x72, 0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x4b, 0x65, 0x79, 0x32, 0x92, 0x01, 0x0a,
-	0x0d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x41,
-	0x0a, 0x05, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x1c, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
-	0x65, 0x72, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72,
-	0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x12, 0x3e, 0x0a, 0x04, 0x53, 0x74, 0x6f, 0x70, 0x12, 0x1b, 0x2e, 0x72, 0x65, 0x63, 0x6f,
-	0x72, 0x64, 0x65, 0x72, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65,
-	0x72, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x42, 0x0c, 0x5a, 0x0a, 0x2e, 0x2f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *StopRecordReply) GetOffsets() map[string]int64 {
+	if x != nil {
+		return x.Offsets
+	}
+	return nil
 }
 
-var (
-	file_proto_recorder_proto_rawDescOnce sync.Once
-	file_proto_recorder_proto_rawDescData = file_proto_recorder_proto_rawDesc
-)
+func (x *StopRecordReply) GetPauses() []*PauseInterval {
+	if x != nil {
+		return x.Pauses
+	}
+	return nil
+}
 
-func file_proto_recorder_proto_rawDescGZIP() []byte {
-	file_proto_recorder_proto_rawDescOnce.Do(func() {
-		file_proto_recorder_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_recorder_proto_rawDescData)
-	})
-	return file_proto_recorder_proto_rawDescData
+func (x *StopRecordReply) GetMetadata() *RecordingMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
 }
 
-var file_proto_recorder_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
-var file_proto_recorder_proto_goTypes = []interface{}{
-	(*StartRecordRequest)(nil), // 0: recorder.StartRecordRequest
-	(*StartRecordReply)(nil),   // 1: recorder.StartRecordReply
-	(*StopRecordRequest)(nil),  // 2: recorder.StopRecordRequest
-	(*StopRecordReply)(nil),    // 3: recorder.StopRecordReply
+func (x *StopRecordReply) GetMix() *MixJob {
+	if x != nil {
+		return x.Mix
+	}
+	return nil
 }
-var file_proto_recorder_proto_depIdxs = []int32{
-	0, // 0: recorder.RecordService.Start:input_type -> recorder.StartRecordRequest
-	2, // 1: recorder.RecordService.Stop:input_type -> recorder.StopRecordRequest
-	1, // 2: recorder.RecordService.Start:output_type -> recorder.StartRecordReply
-	3, // 3: recorder.RecordService.Stop:output_type -> recorder.StopRecordReply
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+
+// A job mixing the tracks of a recording together, running once the recording is stopped
+type MixJob struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// One of pending, running, done, failed
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// Between 0 and 1
+	Progress float32 `protobuf:"fixed32,3,opt,name=progress,proto3" json:"progress,omitempty"`
+	// Key of the mixed track, once done
+	Key   string `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	Error string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
 }
 
-func init() { file_proto_recorder_proto_init() }
-func file_proto_recorder_proto_init() {
-	if File_proto_recorder_proto != nil {
-		return
+func (x *MixJob) Reset() {
+	*x = MixJob{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_recorder_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_proto_recorder_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StartRecordRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+}
+
+func (x *MixJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MixJob) ProtoMessage() {}
+
+func (x *MixJob) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recorder_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_proto_recorder_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StartRecordReply); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MixJob.ProtoReflect.Descriptor instead.
+func (*MixJob) Descriptor() ([]byte, []int) {
+	return file_proto_recorder_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MixJob) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MixJob) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *MixJob) GetProgress() float32 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *MixJob) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *MixJob) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Forcibly end a session that cannot be stopped, for example because Pandora crashed.
+// The session is identified by its id, or by the voice channel it is recording
+type AbortRecordRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VoiceChannelId string `protobuf:"bytes,1,opt,name=voiceChannelId,proto3" json:"voiceChannelId,omitempty"`
+	SessionId      string `protobuf:"bytes,2,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
+	// Why the session is aborted, kept in the recordings history
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *AbortRecordRequest) Reset() {
+	*x = AbortRecordRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_recorder_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AbortRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortRecordRequest) ProtoMessage() {}
+
+func (x *AbortRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recorder_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_proto_recorder_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StopRecordRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortRecordRequest.ProtoReflect.Descriptor instead.
+func (*AbortRecordRequest) Descriptor() ([]byte, []int) {
+	return file_proto_recorder_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AbortRecordRequest) GetVoiceChannelId() string {
+	if x != nil {
+		return x.VoiceChannelId
+	}
+	return ""
+}
+
+func (x *AbortRecordRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *AbortRecordRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type AbortRecordReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Keys of the tracks of the sources that could still be stopped
+	DiscordKeys []string `protobuf:"bytes,1,rep,name=discordKeys,proto3" json:"discordKeys,omitempty"`
+	Roll20Key   string   `protobuf:"bytes,2,opt,name=roll20Key,proto3" json:"roll20Key,omitempty"`
+	// Why each source that could not be stopped failed, by source (discord, roll20)
+	StopFailures map[string]string `protobuf:"bytes,3,rep,name=stopFailures,proto3" json:"stopFailures,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *AbortRecordReply) Reset() {
+	*x = AbortRecordReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_recorder_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AbortRecordReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortRecordReply) ProtoMessage() {}
+
+func (x *AbortRecordReply) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recorder_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortRecordReply.ProtoReflect.Descriptor instead.
+func (*AbortRecordReply) Descriptor() ([]byte, []int) {
+	return file_proto_recorder_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AbortRecordReply) GetDiscordKeys() []string {
+	if x != nil {
+		return x.DiscordKeys
+	}
+	return nil
+}
+
+func (x *AbortRecordReply) GetRoll20Key() string {
+	if x != nil {
+		return x.Roll20Key
+	}
+	return ""
+}
+
+func (x *AbortRecordReply) GetStopFailures() map[string]string {
+	if x != nil {
+		return x.StopFailures
+	}
+	return nil
+}
+
+// Interval during which a recording was paused
+type PauseInterval struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Milliseconds from the start of the earliest track
+	Start int64 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End   int64 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+}
+
+func (x *PauseInterval) Reset() {
+	*x = PauseInterval{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_recorder_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PauseInterval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseInterval) ProtoMessage() {}
+
+func (x *PauseInterval) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recorder_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseInterval.ProtoReflect.Descriptor instead.
+func (*PauseInterval) Descriptor() ([]byte, []int) {
+	return file_proto_recorder_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PauseInterval) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *PauseInterval) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+// The session is either identified by its id or by its voice channel
+type PauseRecordRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VoiceChannelId string `protobuf:"bytes,1,opt,name=voiceChannelId,proto3" json:"voiceChannelId,omitempty"`
+	SessionId      string `protobuf:"bytes,2,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
+}
+
+func (x *PauseRecordRequest) Reset() {
+	*x = PauseRecordRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_recorder_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PauseRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseRecordRequest) ProtoMessage() {}
+
+func (x *PauseRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recorder_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseRecordRequest.ProtoReflect.Descriptor instead.
+func (*PauseRecordRequest) Descriptor() ([]byte, []int) {
+	return file_proto_recorder_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PauseRecordRequest) GetVoiceChannelId() string {
+	if x != nil {
+		return x.VoiceChannelId
+	}
+	return ""
+}
+
+func (x *PauseRecordRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type PauseRecordReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Which sources were paused
+	Discord bool `protobuf:"varint,1,opt,name=discord,proto3" json:"discord,omitempty"`
+	Roll20  bool `protobuf:"varint,2,opt,name=roll20,proto3" json:"roll20,omitempty"`
+}
+
+func (x *PauseRecordReply) Reset() {
+	*x = PauseRecordReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_recorder_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PauseRecordReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseRecordReply) ProtoMessage() {}
+
+func (x *PauseRecordReply) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recorder_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseRecordReply.ProtoReflect.Descriptor instead.
+func (*PauseRecordReply) Descriptor() ([]byte, []int) {
+	return file_proto_recorder_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PauseRecordReply) GetDiscord() bool {
+	if x != nil {
+		return x.Discord
+	}
+	return false
+}
+
+func (x *PauseRecordReply) GetRoll20() bool {
+	if x != nil {
+		return x.Roll20
+	}
+	return false
+}
+
+// The session is either identified by its id or by its voice channel
+type ResumeRecordRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VoiceChannelId string `protobuf:"bytes,1,opt,name=voiceChannelId,proto3" json:"voiceChannelId,omitempty"`
+	SessionId      string `protobuf:"bytes,2,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
+}
+
+func (x *ResumeRecordRequest) Reset() {
+	*x = ResumeRecordRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_recorder_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResumeRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeRecordRequest) ProtoMessage() {}
+
+func (x *ResumeRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recorder_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeRecordRequest.ProtoReflect.Descriptor instead.
+func (*ResumeRecordRequest) Descriptor() ([]byte, []int) {
+	return file_proto_recorder_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ResumeRecordRequest) GetVoiceChannelId() string {
+	if x != nil {
+		return x.VoiceChannelId
+	}
+	return ""
+}
+
+func (x *ResumeRecordRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type ResumeRecordReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Which sources were resumed
+	Discord bool `protobuf:"varint,1,opt,name=discord,proto3" json:"discord,omitempty"`
+	Roll20  bool `protobuf:"varint,2,opt,name=roll20,proto3" json:"roll20,omitempty"`
+}
+
+func (x *ResumeRecordReply) Reset() {
+	*x = ResumeRecordReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_recorder_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResumeRecordReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeRecordReply) ProtoMessage() {}
+
+func (x *ResumeRecordReply) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recorder_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeRecordReply.ProtoReflect.Descriptor instead.
+func (*ResumeRecordReply) Descriptor() ([]byte, []int) {
+	return file_proto_recorder_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ResumeRecordReply) GetDiscord() bool {
+	if x != nil {
+		return x.Discord
+	}
+	return false
+}
+
+func (x *ResumeRecordReply) GetRoll20() bool {
+	if x != nil {
+		return x.Roll20
+	}
+	return false
+}
+
+// The session is either identified by its id or by its voice channel
+type GetRecordingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VoiceChannelId string `protobuf:"bytes,1,opt,name=voiceChannelId,proto3" json:"voiceChannelId,omitempty"`
+	SessionId      string `protobuf:"bytes,2,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
+}
+
+func (x *GetRecordingRequest) Reset() {
+	*x = GetRecordingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_recorder_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRecordingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecordingRequest) ProtoMessage() {}
+
+func (x *GetRecordingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recorder_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecordingRequest.ProtoReflect.Descriptor instead.
+func (*GetRecordingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_recorder_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetRecordingRequest) GetVoiceChannelId() string {
+	if x != nil {
+		return x.VoiceChannelId
+	}
+	return ""
+}
+
+func (x *GetRecordingRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+// Status of a single recording source
+type SourceStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// One of idle, recording, stopped, failed, interrupted
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Last error encountered by the source, if any
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *SourceStatus) Reset() {
+	*x = SourceStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_recorder_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SourceStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SourceStatus) ProtoMessage() {}
+
+func (x *SourceStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recorder_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SourceStatus.ProtoReflect.Descriptor instead.
+func (*SourceStatus) Descriptor() ([]byte, []int) {
+	return file_proto_recorder_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SourceStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SourceStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetRecordingReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Whether a session is currently active on the voice channel.
+	// All the other fields are empty if not
+	Recording      bool   `protobuf:"varint,1,opt,name=recording,proto3" json:"recording,omitempty"`
+	VoiceChannelId string `protobuf:"bytes,2,opt,name=voiceChannelId,proto3" json:"voiceChannelId,omitempty"`
+	Roll20GameId   string `protobuf:"bytes,3,opt,name=roll20GameId,proto3" json:"roll20GameId,omitempty"`
+	// Unix timestamp in milliseconds
+	StartedAt int64         `protobuf:"varint,4,opt,name=startedAt,proto3" json:"startedAt,omitempty"`
+	ElapsedMs int64         `protobuf:"varint,5,opt,name=elapsedMs,proto3" json:"elapsedMs,omitempty"`
+	Discord   *SourceStatus `protobuf:"bytes,6,opt,name=discord,proto3" json:"discord,omitempty"`
+	Roll20    *SourceStatus `protobuf:"bytes,7,opt,name=roll20,proto3" json:"roll20,omitempty"`
+	// Lifecycle phase, one of starting, recording, paused, stopping, compensating
+	Phase     string             `protobuf:"bytes,8,opt,name=phase,proto3" json:"phase,omitempty"`
+	SessionId string             `protobuf:"bytes,9,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
+	Metadata  *RecordingMetadata `protobuf:"bytes,10,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Pandora instance recording the voice channel, empty if Pandora doesn't send heartbeats
+	PandoraInstance string `protobuf:"bytes,11,opt,name=pandoraInstance,proto3" json:"pandoraInstance,omitempty"`
+}
+
+func (x *GetRecordingReply) Reset() {
+	*x = GetRecordingReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_recorder_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRecordingReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecordingReply) ProtoMessage() {}
+
+func (x *GetRecordingReply) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recorder_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecordingReply.ProtoReflect.Descriptor instead.
+func (*GetRecordingReply) Descriptor() ([]byte, []int) {
+	return file_proto_recorder_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetRecordingReply) GetRecording() bool {
+	if x != nil {
+		return x.Recording
+	}
+	return false
+}
+
+func (x *GetRecordingReply) GetVoiceChannelId() string {
+	if x != nil {
+		return x.VoiceChannelId
+	}
+	return ""
+}
+
+func (x *GetRecordingReply) GetRoll20GameId() string {
+	if x != nil {
+		return x.Roll20GameId
+	}
+	return ""
+}
+
+func (x *GetRecordingReply) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *GetRecordingReply) GetElapsedMs() int64 {
+	if x != nil {
+		return x.ElapsedMs
+	}
+	return 0
+}
+
+func (x *GetRecordingReply) GetDiscord() *SourceStatus {
+	if x != nil {
+		return x.Discord
+	}
+	return nil
+}
+
+func (x *GetRecordingReply) GetRoll20() *SourceStatus {
+	if x != nil {
+		return x.Roll20
+	}
+	return nil
+}
+
+func (x *GetRecordingReply) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *GetRecordingReply) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *GetRecordingReply) GetMetadata() *RecordingMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *GetRecordingReply) GetPandoraInstance() string {
+	if x != nil {
+		return x.PandoraInstance
+	}
+	return ""
+}
+
+type ListRecordingsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Filters, ignored when empty
+	VoiceChannelId string `protobuf:"bytes,1,opt,name=voiceChannelId,proto3" json:"voiceChannelId,omitempty"`
+	Roll20GameId   string `protobuf:"bytes,2,opt,name=roll20GameId,proto3" json:"roll20GameId,omitempty"`
+	// Only recordings started between from and to, unix timestamps in milliseconds.
+	// 0 leaves the range open on that side
+	From int64 `protobuf:"varint,3,opt,name=from,proto3" json:"from,omitempty"`
+	To   int64 `protobuf:"varint,4,opt,name=to,proto3" json:"to,omitempty"`
+	// Maximum number of recordings returned, defaults to 20 and cannot exceed 100
+	PageSize int32 `protobuf:"varint,5,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	// Token returned by the previous call, to get the next page
+	PageToken string `protobuf:"bytes,6,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	// Only recordings of this campaign, as given in their metadata
+	Campaign string `protobuf:"bytes,7,opt,name=campaign,proto3" json:"campaign,omitempty"`
+}
+
+func (x *ListRecordingsRequest) Reset() {
+	*x = ListRecordingsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_recorder_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRecordingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecordingsRequest) ProtoMessage() {}
+
+func (x *ListRecordingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recorder_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecordingsRequest.ProtoReflect.Descriptor instead.
+func (*ListRecordingsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_recorder_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListRecordingsRequest) GetVoiceChannelId() string {
+	if x != nil {
+		return x.VoiceChannelId
+	}
+	return ""
+}
+
+func (x *ListRecordingsRequest) GetRoll20GameId() string {
+	if x != nil {
+		return x.Roll20GameId
+	}
+	return ""
+}
+
+func (x *ListRecordingsRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *ListRecordingsRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *ListRecordingsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListRecordingsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListRecordingsRequest) GetCampaign() string {
+	if x != nil {
+		return x.Campaign
+	}
+	return ""
+}
+
+// A recording session that ended
+type Recording struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id             string           `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	VoiceChannelId string           `protobuf:"bytes,2,opt,name=voiceChannelId,proto3" json:"voiceChannelId,omitempty"`
+	Roll20GameId   string           `protobuf:"bytes,3,opt,name=roll20GameId,proto3" json:"roll20GameId,omitempty"`
+	DiscordKeys    []string         `protobuf:"bytes,4,rep,name=discordKeys,proto3" json:"discordKeys,omitempty"`
+	Roll20Key      string           `protobuf:"bytes,5,opt,name=roll20Key,proto3" json:"roll20Key,omitempty"`
+	Offsets        map[string]int64 `protobuf:"bytes,6,rep,name=offsets,proto3" json:"offsets,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Unix timestamps in milliseconds
+	StartedAt int64            `protobuf:"varint,7,opt,name=startedAt,proto3" json:"startedAt,omitempty"`
+	StoppedAt int64            `protobuf:"varint,8,opt,name=stoppedAt,proto3" json:"stoppedAt,omitempty"`
+	Requester string           `protobuf:"bytes,9,opt,name=requester,proto3" json:"requester,omitempty"`
+	Pauses    []*PauseInterval `protobuf:"bytes,10,rep,name=pauses,proto3" json:"pauses,omitempty"`
+	// Whether the recording was stopped by the orchestrator as it reached its maximum duration
+	AutoStopped bool `protobuf:"varint,11,opt,name=autoStopped,proto3" json:"autoStopped,omitempty"`
+	// Id of the session, as returned by Start
+	SessionId string             `protobuf:"bytes,12,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
+	Metadata  *RecordingMetadata `protobuf:"bytes,13,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Mix       *MixJob            `protobuf:"bytes,14,opt,name=mix,proto3" json:"mix,omitempty"`
+	// Whether the recording was aborted, why, and why its sources could not be stopped if they couldn't
+	Aborted      bool              `protobuf:"varint,15,opt,name=aborted,proto3" json:"aborted,omitempty"`
+	AbortReason  string            `protobuf:"bytes,16,opt,name=abortReason,proto3" json:"abortReason,omitempty"`
+	StopFailures map[string]string `protobuf:"bytes,17,rep,name=stopFailures,proto3" json:"stopFailures,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Recording) Reset() {
+	*x = Recording{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_recorder_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Recording) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Recording) ProtoMessage() {}
+
+func (x *Recording) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recorder_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Recording.ProtoReflect.Descriptor instead.
+func (*Recording) Descriptor() ([]byte, []int) {
+	return file_proto_recorder_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Recording) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Recording) GetVoiceChannelId() string {
+	if x != nil {
+		return x.VoiceChannelId
+	}
+	return ""
+}
+
+func (x *Recording) GetRoll20GameId() string {
+	if x != nil {
+		return x.Roll20GameId
+	}
+	return ""
+}
+
+func (x *Recording) GetDiscordKeys() []string {
+	if x != nil {
+		return x.DiscordKeys
+	}
+	return nil
+}
+
+func (x *Recording) GetRoll20Key() string {
+	if x != nil {
+		return x.Roll20Key
+	}
+	return ""
+}
+
+func (x *Recording) GetOffsets() map[string]int64 {
+	if x != nil {
+		return x.Offsets
+	}
+	return nil
+}
+
+func (x *Recording) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *Recording) GetStoppedAt() int64 {
+	if x != nil {
+		return x.StoppedAt
+	}
+	return 0
+}
+
+func (x *Recording) GetRequester() string {
+	if x != nil {
+		return x.Requester
+	}
+	return ""
+}
+
+func (x *Recording) GetPauses() []*PauseInterval {
+	if x != nil {
+		return x.Pauses
+	}
+	return nil
+}
+
+func (x *Recording) GetAutoStopped() bool {
+	if x != nil {
+		return x.AutoStopped
+	}
+	return false
+}
+
+func (x *Recording) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *Recording) GetMetadata() *RecordingMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Recording) GetMix() *MixJob {
+	if x != nil {
+		return x.Mix
+	}
+	return nil
+}
+
+func (x *Recording) GetAborted() bool {
+	if x != nil {
+		return x.Aborted
+	}
+	return false
+}
+
+func (x *Recording) GetAbortReason() string {
+	if x != nil {
+		return x.AbortReason
+	}
+	return ""
+}
+
+func (x *Recording) GetStopFailures() map[string]string {
+	if x != nil {
+		return x.StopFailures
+	}
+	return nil
+}
+
+type ListRecordingsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Most recent recordings first
+	Recordings []*Recording `protobuf:"bytes,1,rep,name=recordings,proto3" json:"recordings,omitempty"`
+	// Empty on the last page. A page may hold fewer recordings than asked for before that
+	NextPageToken string `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+}
+
+func (x *ListRecordingsReply) Reset() {
+	*x = ListRecordingsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_recorder_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRecordingsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecordingsReply) ProtoMessage() {}
+
+func (x *ListRecordingsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recorder_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecordingsReply.ProtoReflect.Descriptor instead.
+func (*ListRecordingsReply) Descriptor() ([]byte, []int) {
+	return file_proto_recorder_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListRecordingsReply) GetRecordings() []*Recording {
+	if x != nil {
+		return x.Recordings
+	}
+	return nil
+}
+
+func (x *ListRecordingsReply) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type WatchRecordingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Only the events of this voice channel, every event if empty
+	VoiceChannelId string `protobuf:"bytes,1,opt,name=voiceChannelId,proto3" json:"voiceChannelId,omitempty"`
+}
+
+func (x *WatchRecordingRequest) Reset() {
+	*x = WatchRecordingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_recorder_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchRecordingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRecordingRequest) ProtoMessage() {}
+
+func (x *WatchRecordingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recorder_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRecordingRequest.ProtoReflect.Descriptor instead.
+func (*WatchRecordingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_recorder_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *WatchRecordingRequest) GetVoiceChannelId() string {
+	if x != nil {
+		return x.VoiceChannelId
+	}
+	return ""
+}
+
+// Something that happened during the lifecycle of a session
+type RecordingEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// One of startRequested, discordAcknowledged, roll20Attached, recordingStarted, warning,
+	// recordingPaused, recordingResumed, stopRequested, tracksUploaded, recordingStopped,
+	// recordingPartiallyStopped, recordingFailed, recordingAborted, recordingInterrupted,
+	// recordingReconciled, recordingMixed, mixFailed
+	Kind           string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	VoiceChannelId string `protobuf:"bytes,2,opt,name=voiceChannelId,proto3" json:"voiceChannelId,omitempty"`
+	Roll20GameId   string `protobuf:"bytes,3,opt,name=roll20GameId,proto3" json:"roll20GameId,omitempty"`
+	// Phase of the session once the event occurred
+	Phase       string   `protobuf:"bytes,4,opt,name=phase,proto3" json:"phase,omitempty"`
+	Message     string   `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	DiscordKeys []string `protobuf:"bytes,6,rep,name=discordKeys,proto3" json:"discordKeys,omitempty"`
+	Roll20Key   string   `protobuf:"bytes,7,opt,name=roll20Key,proto3" json:"roll20Key,omitempty"`
+	// Unix timestamp in milliseconds
+	At        int64              `protobuf:"varint,8,opt,name=at,proto3" json:"at,omitempty"`
+	SessionId string             `protobuf:"bytes,9,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
+	Metadata  *RecordingMetadata `protobuf:"bytes,10,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Key of the mixed track, for recordingMixed events
+	MixKey string `protobuf:"bytes,11,opt,name=mixKey,proto3" json:"mixKey,omitempty"`
+}
+
+func (x *RecordingEvent) Reset() {
+	*x = RecordingEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_recorder_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordingEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordingEvent) ProtoMessage() {}
+
+func (x *RecordingEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recorder_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordingEvent.ProtoReflect.Descriptor instead.
+func (*RecordingEvent) Descriptor() ([]byte, []int) {
+	return file_proto_recorder_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RecordingEvent) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *RecordingEvent) GetVoiceChannelId() string {
+	if x != nil {
+		return x.VoiceChannelId
+	}
+	return ""
+}
+
+func (x *RecordingEvent) GetRoll20GameId() string {
+	if x != nil {
+		return x.Roll20GameId
+	}
+	return ""
+}
+
+func (x *RecordingEvent) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *RecordingEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RecordingEvent) GetDiscordKeys() []string {
+	if x != nil {
+		return x.DiscordKeys
+	}
+	return nil
+}
+
+func (x *RecordingEvent) GetRoll20Key() string {
+	if x != nil {
+		return x.Roll20Key
+	}
+	return ""
+}
+
+func (x *RecordingEvent) GetAt() int64 {
+	if x != nil {
+		return x.At
+	}
+	return 0
+}
+
+func (x *RecordingEvent) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *RecordingEvent) GetMetadata() *RecordingMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *RecordingEvent) GetMixKey() string {
+	if x != nil {
+		return x.MixKey
+	}
+	return ""
+}
+
+type ScheduleRecordRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VoiceChannelId string `protobuf:"bytes,1,opt,name=voiceChannelId,proto3" json:"voiceChannelId,omitempty"`
+	Roll20GameId   string `protobuf:"bytes,2,opt,name=roll20GameId,proto3" json:"roll20GameId,omitempty"`
+	// When to start recording, unix timestamp in milliseconds
+	StartAt int64 `protobuf:"varint,3,opt,name=startAt,proto3" json:"startAt,omitempty"`
+	// How long to record, counted from startAt
+	MaxDurationMs int64  `protobuf:"varint,4,opt,name=maxDurationMs,proto3" json:"maxDurationMs,omitempty"`
+	Requester     string `protobuf:"bytes,5,opt,name=requester,proto3" json:"requester,omitempty"`
+	// Given to the recording once started
+	Metadata *RecordingMetadata `protobuf:"bytes,6,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Mix the tracks together once the recording is stopped
+	Mix bool `protobuf:"varint,7,opt,name=mix,proto3" json:"mix,omitempty"`
+}
+
+func (x *ScheduleRecordRequest) Reset() {
+	*x = ScheduleRecordRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_recorder_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduleRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleRecordRequest) ProtoMessage() {}
+
+func (x *ScheduleRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recorder_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleRecordRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleRecordRequest) Descriptor() ([]byte, []int) {
+	return file_proto_recorder_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ScheduleRecordRequest) GetVoiceChannelId() string {
+	if x != nil {
+		return x.VoiceChannelId
+	}
+	return ""
+}
+
+func (x *ScheduleRecordRequest) GetRoll20GameId() string {
+	if x != nil {
+		return x.Roll20GameId
+	}
+	return ""
+}
+
+func (x *ScheduleRecordRequest) GetStartAt() int64 {
+	if x != nil {
+		return x.StartAt
+	}
+	return 0
+}
+
+func (x *ScheduleRecordRequest) GetMaxDurationMs() int64 {
+	if x != nil {
+		return x.MaxDurationMs
+	}
+	return 0
+}
+
+func (x *ScheduleRecordRequest) GetRequester() string {
+	if x != nil {
+		return x.Requester
+	}
+	return ""
+}
+
+func (x *ScheduleRecordRequest) GetMetadata() *RecordingMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ScheduleRecordRequest) GetMix() bool {
+	if x != nil {
+		return x.Mix
+	}
+	return false
+}
+
+// A recording planned in advance
+type ScheduledRecording struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id             string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	VoiceChannelId string `protobuf:"bytes,2,opt,name=voiceChannelId,proto3" json:"voiceChannelId,omitempty"`
+	Roll20GameId   string `protobuf:"bytes,3,opt,name=roll20GameId,proto3" json:"roll20GameId,omitempty"`
+	StartAt        int64  `protobuf:"varint,4,opt,name=startAt,proto3" json:"startAt,omitempty"`
+	MaxDurationMs  int64  `protobuf:"varint,5,opt,name=maxDurationMs,proto3" json:"maxDurationMs,omitempty"`
+	Requester      string `protobuf:"bytes,6,opt,name=requester,proto3" json:"requester,omitempty"`
+	// One of pending, failed
+	Status string `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	// Why the schedule failed, if it did
+	Error    string             `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	Metadata *RecordingMetadata `protobuf:"bytes,9,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Mix      bool               `protobuf:"varint,10,opt,name=mix,proto3" json:"mix,omitempty"`
+}
+
+func (x *ScheduledRecording) Reset() {
+	*x = ScheduledRecording{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_recorder_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduledRecording) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledRecording) ProtoMessage() {}
+
+func (x *ScheduledRecording) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recorder_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledRecording.ProtoReflect.Descriptor instead.
+func (*ScheduledRecording) Descriptor() ([]byte, []int) {
+	return file_proto_recorder_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ScheduledRecording) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScheduledRecording) GetVoiceChannelId() string {
+	if x != nil {
+		return x.VoiceChannelId
+	}
+	return ""
+}
+
+func (x *ScheduledRecording) GetRoll20GameId() string {
+	if x != nil {
+		return x.Roll20GameId
+	}
+	return ""
+}
+
+func (x *ScheduledRecording) GetStartAt() int64 {
+	if x != nil {
+		return x.StartAt
+	}
+	return 0
+}
+
+func (x *ScheduledRecording) GetMaxDurationMs() int64 {
+	if x != nil {
+		return x.MaxDurationMs
+	}
+	return 0
+}
+
+func (x *ScheduledRecording) GetRequester() string {
+	if x != nil {
+		return x.Requester
+	}
+	return ""
+}
+
+func (x *ScheduledRecording) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ScheduledRecording) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ScheduledRecording) GetMetadata() *RecordingMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ScheduledRecording) GetMix() bool {
+	if x != nil {
+		return x.Mix
+	}
+	return false
+}
+
+type ListSchedulesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Only the schedules of this voice channel, every schedule if empty
+	VoiceChannelId string `protobuf:"bytes,1,opt,name=voiceChannelId,proto3" json:"voiceChannelId,omitempty"`
+}
+
+func (x *ListSchedulesRequest) Reset() {
+	*x = ListSchedulesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_recorder_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSchedulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchedulesRequest) ProtoMessage() {}
+
+func (x *ListSchedulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recorder_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchedulesRequest.ProtoReflect.Descriptor instead.
+func (*ListSchedulesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_recorder_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListSchedulesRequest) GetVoiceChannelId() string {
+	if x != nil {
+		return x.VoiceChannelId
+	}
+	return ""
+}
+
+type ListSchedulesReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Soonest first
+	Schedules []*ScheduledRecording `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+}
+
+func (x *ListSchedulesReply) Reset() {
+	*x = ListSchedulesReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_recorder_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSchedulesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchedulesReply) ProtoMessage() {}
+
+func (x *ListSchedulesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recorder_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchedulesReply.ProtoReflect.Descriptor instead.
+func (*ListSchedulesReply) Descriptor() ([]byte, []int) {
+	return file_proto_recorder_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListSchedulesReply) GetSchedules() []*ScheduledRecording {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+type CancelScheduleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *CancelScheduleRequest) Reset() {
+	*x = CancelScheduleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_recorder_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduleRequest) ProtoMessage() {}
+
+func (x *CancelScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recorder_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduleRequest.ProtoReflect.Descriptor instead.
+func (*CancelScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_recorder_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CancelScheduleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CancelScheduleReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CancelScheduleReply) Reset() {
+	*x = CancelScheduleReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_recorder_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelScheduleReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduleReply) ProtoMessage() {}
+
+func (x *CancelScheduleReply) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recorder_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduleReply.ProtoReflect.Descriptor instead.
+func (*CancelScheduleReply) Descriptor() ([]byte, []int) {
+	return file_proto_recorder_proto_rawDescGZIP(), []int{26}
+}
+
+type GetHealthRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetHealthRequest) Reset() {
+	*x = GetHealthRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_recorder_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHealthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHealthRequest) ProtoMessage() {}
+
+func (x *GetHealthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recorder_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHealthRequest.ProtoReflect.Descriptor instead.
+func (*GetHealthRequest) Descriptor() ([]byte, []int) {
+	return file_proto_recorder_proto_rawDescGZIP(), []int{27}
+}
+
+// A Pandora instance that sent a heartbeat recently
+type PandoraInstance struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Voice channel the instance is recording, if any
+	VoiceChannelId string `protobuf:"bytes,3,opt,name=voiceChannelId,proto3" json:"voiceChannelId,omitempty"`
+	// Unix timestamp in milliseconds of its last heartbeat
+	LastSeen int64 `protobuf:"varint,4,opt,name=lastSeen,proto3" json:"lastSeen,omitempty"`
+	// Version of the message schema it speaks, 0 if it didn't say
+	SchemaVersion int32 `protobuf:"varint,5,opt,name=schemaVersion,proto3" json:"schemaVersion,omitempty"`
+}
+
+func (x *PandoraInstance) Reset() {
+	*x = PandoraInstance{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_recorder_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PandoraInstance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PandoraInstance) ProtoMessage() {}
+
+func (x *PandoraInstance) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recorder_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PandoraInstance.ProtoReflect.Descriptor instead.
+func (*PandoraInstance) Descriptor() ([]byte, []int) {
+	return file_proto_recorder_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PandoraInstance) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PandoraInstance) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *PandoraInstance) GetVoiceChannelId() string {
+	if x != nil {
+		return x.VoiceChannelId
+	}
+	return ""
+}
+
+func (x *PandoraInstance) GetLastSeen() int64 {
+	if x != nil {
+		return x.LastSeen
+	}
+	return 0
+}
+
+func (x *PandoraInstance) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+type GetHealthReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// One of up, down, or unknown until Pandora sends a first heartbeat
+	Pandora string `protobuf:"bytes,1,opt,name=pandora,proto3" json:"pandora,omitempty"`
+	// Unix timestamp in milliseconds of the last heartbeat of any instance, 0 if none
+	PandoraLastSeen  int64              `protobuf:"varint,2,opt,name=pandoraLastSeen,proto3" json:"pandoraLastSeen,omitempty"`
+	PandoraInstances []*PandoraInstance `protobuf:"bytes,3,rep,name=pandoraInstances,proto3" json:"pandoraInstances,omitempty"`
+	// Version of the message schema Pandora must speak
+	PandoraSchemaVersion int32 `protobuf:"varint,4,opt,name=pandoraSchemaVersion,proto3" json:"pandoraSchemaVersion,omitempty"`
+}
+
+func (x *GetHealthReply) Reset() {
+	*x = GetHealthReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_recorder_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHealthReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHealthReply) ProtoMessage() {}
+
+func (x *GetHealthReply) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_recorder_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHealthReply.ProtoReflect.Descriptor instead.
+func (*GetHealthReply) Descriptor() ([]byte, []int) {
+	return file_proto_recorder_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetHealthReply) GetPandora() string {
+	if x != nil {
+		return x.Pandora
+	}
+	return ""
+}
+
+func (x *GetHealthReply) GetPandoraLastSeen() int64 {
+	if x != nil {
+		return x.PandoraLastSeen
+	}
+	return 0
+}
+
+func (x *GetHealthReply) GetPandoraInstances() []*PandoraInstance {
+	if x != nil {
+		return x.PandoraInstances
+	}
+	return nil
+}
+
+func (x *GetHealthReply) GetPandoraSchemaVersion() int32 {
+	if x != nil {
+		return x.PandoraSchemaVersion
+	}
+	return 0
+}
+
+var File_proto_recorder_proto protoreflect.FileDescriptor
+
+var file_proto_recorder_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x22, 0xe7, 0x01, 0x0a, 0x11, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x63, 0x61, 0x6d, 0x70, 0x61, 0x69, 0x67, 0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0d, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x3f,
+	0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27,
+	0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x69, 0x6e, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x1a,
+	0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x97, 0x02, 0x0a, 0x12, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x26, 0x0a, 0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65,
+	0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x6f, 0x6c,
+	0x6c, 0x32, 0x30, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x72, 0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x1c, 0x0a,
+	0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x12, 0x24, 0x0a, 0x0d, 0x6d,
+	0x61, 0x78, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d,
+	0x73, 0x12, 0x37, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69,
+	0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x6d, 0x69, 0x78, 0x12, 0x26, 0x0a, 0x0e,
+	0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63,
+	0x79, 0x4b, 0x65, 0x79, 0x22, 0x62, 0x0a, 0x10, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x69, 0x73, 0x63,
+	0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x64, 0x69, 0x73, 0x63, 0x6f,
+	0x72, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0xb7, 0x01, 0x0a, 0x11, 0x53, 0x74, 0x6f,
+	0x70, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26,
+	0x0a, 0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x6f, 0x6c, 0x6c, 0x32, 0x30,
+	0x47, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x6f,
+	0x6c, 0x6c, 0x32, 0x30, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x78, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x6d, 0x69, 0x78, 0x12, 0x26, 0x0a, 0x0e, 0x69, 0x64,
+	0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b,
+	0x65, 0x79, 0x22, 0xdd, 0x02, 0x0a, 0x0f, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x72,
+	0x64, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73,
+	0x63, 0x6f, 0x72, 0x64, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x6f, 0x6c, 0x6c,
+	0x32, 0x30, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x6f, 0x6c,
+	0x6c, 0x32, 0x30, 0x4b, 0x65, 0x79, 0x12, 0x40, 0x0a, 0x07, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x2e, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x07, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x73, 0x12, 0x2f, 0x0a, 0x06, 0x70, 0x61, 0x75, 0x73,
+	0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x2e, 0x50, 0x61, 0x75, 0x73, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x52, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x73, 0x12, 0x37, 0x0a, 0x08, 0x6d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x22, 0x0a, 0x03, 0x6d, 0x69, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x4d, 0x69, 0x78, 0x4a, 0x6f,
+	0x62, 0x52, 0x03, 0x6d, 0x69, 0x78, 0x1a, 0x3a, 0x0a, 0x0c, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x74, 0x0a, 0x06, 0x4d, 0x69, 0x78, 0x4a, 0x6f, 0x62, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x72, 0x0a, 0x12, 0x41, 0x62, 0x6f, 0x72,
+	0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26,
+	0x0a, 0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0xe5, 0x01, 0x0a,
+	0x10, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x64, 0x4b, 0x65, 0x79, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x64, 0x4b,
+	0x65, 0x79, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x4b, 0x65, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x4b, 0x65,
+	0x79, 0x12, 0x50, 0x0a, 0x0c, 0x73, 0x74, 0x6f, 0x70, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x2e, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x73, 0x74, 0x6f, 0x70, 0x46, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x73, 0x1a, 0x3f, 0x0a, 0x11, 0x53, 0x74, 0x6f, 0x70, 0x46, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x37, 0x0a, 0x0d, 0x50, 0x61, 0x75, 0x73, 0x65, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65,
+	0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x22, 0x5a, 0x0a,
+	0x12, 0x50, 0x61, 0x75, 0x73, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x76, 0x6f, 0x69,
+	0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x44, 0x0a, 0x10, 0x50, 0x61, 0x75,
+	0x73, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a,
+	0x07, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x64, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6c, 0x6c, 0x32,
+	0x30, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x22,
+	0x5b, 0x0a, 0x13, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x43,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x76, 0x6f, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x45, 0x0a, 0x11,
+	0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x6f, 0x6c,
+	0x6c, 0x32, 0x30, 0x22, 0x5b, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x76, 0x6f,
+	0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x22, 0x3c, 0x0a, 0x0c, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xb2,
+	0x03, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e,
+	0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69,
+	0x6e, 0x67, 0x12, 0x26, 0x0a, 0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x76, 0x6f, 0x69, 0x63,
+	0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x6f,
+	0x6c, 0x6c, 0x32, 0x30, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x72, 0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x65, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x4d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x65, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x4d, 0x73, 0x12, 0x30, 0x0a, 0x07, 0x64, 0x69,
+	0x73, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x07, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x2e, 0x0a, 0x06,
+	0x72, 0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x72,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x12, 0x14, 0x0a, 0x05,
+	0x70, 0x68, 0x61, 0x73, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x61,
+	0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x12, 0x37, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x28, 0x0a, 0x0f, 0x70, 0x61, 0x6e,
+	0x64, 0x6f, 0x72, 0x61, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0f, 0x70, 0x61, 0x6e, 0x64, 0x6f, 0x72, 0x61, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x22, 0xdd, 0x01, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a,
+	0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x47,
+	0x61, 0x6d, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x6f, 0x6c,
+	0x6c, 0x32, 0x30, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f,
+	0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a,
+	0x02, 0x74, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x67,
+	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61,
+	0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x6d, 0x70, 0x61,
+	0x69, 0x67, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x6d, 0x70, 0x61,
+	0x69, 0x67, 0x6e, 0x22, 0x8f, 0x06, 0x0a, 0x09, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e,
+	0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x26, 0x0a, 0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65,
+	0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x6f, 0x6c,
+	0x6c, 0x32, 0x30, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x72, 0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x20, 0x0a,
+	0x0b, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x64, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x64, 0x4b, 0x65, 0x79, 0x73, 0x12,
+	0x1c, 0x0a, 0x09, 0x72, 0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x4b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x72, 0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x4b, 0x65, 0x79, 0x12, 0x3a, 0x0a,
+	0x07, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x69, 0x6e, 0x67, 0x2e, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x07, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x6f, 0x70, 0x70,
+	0x65, 0x64, 0x41, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x6f, 0x70,
+	0x70, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x65, 0x72, 0x12, 0x2f, 0x0a, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x73, 0x18, 0x0a, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x50,
+	0x61, 0x75, 0x73, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x52, 0x06, 0x70, 0x61,
+	0x75, 0x73, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x75, 0x74, 0x6f, 0x53, 0x74, 0x6f, 0x70,
+	0x70, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x61, 0x75, 0x74, 0x6f, 0x53,
+	0x74, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x49, 0x64, 0x12, 0x37, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x22, 0x0a,
+	0x03, 0x6d, 0x69, 0x78, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x72, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x4d, 0x69, 0x78, 0x4a, 0x6f, 0x62, 0x52, 0x03, 0x6d, 0x69,
+	0x78, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x62, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x61, 0x62, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x61,
+	0x62, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x61, 0x62, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x49, 0x0a,
+	0x0c, 0x73, 0x74, 0x6f, 0x70, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x11, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x46, 0x61, 0x69,
+	0x6c, 0x75, 0x72, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x73, 0x74, 0x6f, 0x70,
+	0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x4f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3f, 0x0a, 0x11, 0x53, 0x74, 0x6f, 0x70, 0x46, 0x61, 0x69, 0x6c,
+	0x75, 0x72, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x70, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x33, 0x0a, 0x0a,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67,
+	0x73, 0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61,
+	0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x3f, 0x0a, 0x15, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x26, 0x0a, 0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x43,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x22, 0xdf, 0x02, 0x0a, 0x0e, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12,
+	0x26, 0x0a, 0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x43, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x6f, 0x6c, 0x6c, 0x32,
+	0x30, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72,
+	0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x70,
+	0x68, 0x61, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x61, 0x73,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64,
+	0x69, 0x73, 0x63, 0x6f, 0x72, 0x64, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0b, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x64, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x1c, 0x0a,
+	0x09, 0x72, 0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x4b, 0x65, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x72, 0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x4b, 0x65, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x61,
+	0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x61, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x37, 0x0a, 0x08, 0x6d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x69, 0x78, 0x4b, 0x65, 0x79, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6d, 0x69, 0x78, 0x4b, 0x65, 0x79, 0x22, 0x8c, 0x02, 0x0a, 0x15, 0x53,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x76, 0x6f,
+	0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c,
+	0x72, 0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x72, 0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x6d, 0x61,
+	0x78, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73,
+	0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x12, 0x37,
+	0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x69, 0x6e, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x78, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x6d, 0x69, 0x78, 0x22, 0xc7, 0x02, 0x0a, 0x12, 0x53, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x26, 0x0a, 0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x43,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x6f, 0x6c, 0x6c,
+	0x32, 0x30, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x72, 0x6f, 0x6c, 0x6c, 0x32, 0x30, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x41, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6d,
+	0x61, 0x78, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x12, 0x1c, 0x0a, 0x09,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x37, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x72, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x78, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03,
+	0x6d, 0x69, 0x78, 0x22, 0x3e, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x76,
+	0x6f, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x49, 0x64, 0x22, 0x50, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3a, 0x0a, 0x09, 0x73, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x72,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x09, 0x73, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x73, 0x22, 0x27, 0x0a, 0x15, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x15,
+	0x0a, 0x13, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x12, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xa5, 0x01, 0x0a, 0x0f, 0x50, 0x61,
+	0x6e, 0x64, 0x6f, 0x72, 0x61, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65,
+	0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12,
+	0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0d, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x22, 0xcf, 0x01, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x6e, 0x64, 0x6f, 0x72, 0x61, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x6e, 0x64, 0x6f, 0x72, 0x61, 0x12, 0x28,
+	0x0a, 0x0f, 0x70, 0x61, 0x6e, 0x64, 0x6f, 0x72, 0x61, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x70, 0x61, 0x6e, 0x64, 0x6f, 0x72, 0x61,
+	0x4c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x12, 0x45, 0x0a, 0x10, 0x70, 0x61, 0x6e, 0x64,
+	0x6f, 0x72, 0x61, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x50, 0x61,
+	0x6e, 0x64, 0x6f, 0x72, 0x61, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x10, 0x70,
+	0x61, 0x6e, 0x64, 0x6f, 0x72, 0x61, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12,
+	0x32, 0x0a, 0x14, 0x70, 0x61, 0x6e, 0x64, 0x6f, 0x72, 0x61, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x14, 0x70,
+	0x61, 0x6e, 0x64, 0x6f, 0x72, 0x61, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x32, 0x83, 0x07, 0x0a, 0x0d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x41, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x1c,
+	0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x72,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3e, 0x0a, 0x04, 0x53, 0x74, 0x6f, 0x70,
+	0x12, 0x1b, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x53, 0x74, 0x6f, 0x70,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x41, 0x0a, 0x05, 0x50, 0x61, 0x75, 0x73,
+	0x65, 0x12, 0x1c, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x50, 0x61, 0x75,
+	0x73, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1a, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x50, 0x61, 0x75, 0x73, 0x65,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x44, 0x0a, 0x06, 0x52,
+	0x65, 0x73, 0x75, 0x6d, 0x65, 0x12, 0x1d, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x2e, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e,
+	0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x4a, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e,
+	0x67, 0x12, 0x1d, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1b, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x50, 0x0a,
+	0x0e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x12,
+	0x1f, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1d, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x4d, 0x0a, 0x0e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e,
+	0x67, 0x12, 0x1f, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x18, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x12, 0x52,
+	0x0a, 0x11, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x69, 0x6e, 0x67, 0x12, 0x1f, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x53,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e,
+	0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69,
+	0x6e, 0x67, 0x12, 0x4d, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x73, 0x12, 0x1e, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x50, 0x0a, 0x0e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x12, 0x1f, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x43,
+	0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e,
+	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x41, 0x0a, 0x05, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x12, 0x1c, 0x2e, 0x72,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x72, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x41, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61,
+	0x6c, 0x74, 0x68, 0x12, 0x1a, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x47,
+	0x65, 0x74, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x18, 0x2e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x42, 0x0c, 0x5a, 0x0a, 0x2e, 0x2f, 0x72,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_recorder_proto_rawDescOnce sync.Once
+	file_proto_recorder_proto_rawDescData = file_proto_recorder_proto_rawDesc
+)
+
+func file_proto_recorder_proto_rawDescGZIP() []byte {
+	file_proto_recorder_proto_rawDescOnce.Do(func() {
+		file_proto_recorder_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_recorder_proto_rawDescData)
+	})
+	return file_proto_recorder_proto_rawDescData
+}
+
+var file_proto_recorder_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
+var file_proto_recorder_proto_goTypes = []interface{}{
+	(*RecordingMetadata)(nil),     // 0: recorder.RecordingMetadata
+	(*StartRecordRequest)(nil),    // 1: recorder.StartRecordRequest
+	(*StartRecordReply)(nil),      // 2: recorder.StartRecordReply
+	(*StopRecordRequest)(nil),     // 3: recorder.StopRecordRequest
+	(*StopRecordReply)(nil),       // 4: recorder.StopRecordReply
+	(*MixJob)(nil),                // 5: recorder.MixJob
+	(*AbortRecordRequest)(nil),    // 6: recorder.AbortRecordRequest
+	(*AbortRecordReply)(nil),      // 7: recorder.AbortRecordReply
+	(*PauseInterval)(nil),         // 8: recorder.PauseInterval
+	(*PauseRecordRequest)(nil),    // 9: recorder.PauseRecordRequest
+	(*PauseRecordReply)(nil),      // 10: recorder.PauseRecordReply
+	(*ResumeRecordRequest)(nil),   // 11: recorder.ResumeRecordRequest
+	(*ResumeRecordReply)(nil),     // 12: recorder.ResumeRecordReply
+	(*GetRecordingRequest)(nil),   // 13: recorder.GetRecordingRequest
+	(*SourceStatus)(nil),          // 14: recorder.SourceStatus
+	(*GetRecordingReply)(nil),     // 15: recorder.GetRecordingReply
+	(*ListRecordingsRequest)(nil), // 16: recorder.ListRecordingsRequest
+	(*Recording)(nil),             // 17: recorder.Recording
+	(*ListRecordingsReply)(nil),   // 18: recorder.ListRecordingsReply
+	(*WatchRecordingRequest)(nil), // 19: recorder.WatchRecordingRequest
+	(*RecordingEvent)(nil),        // 20: recorder.RecordingEvent
+	(*ScheduleRecordRequest)(nil), // 21: recorder.ScheduleRecordRequest
+	(*ScheduledRecording)(nil),    // 22: recorder.ScheduledRecording
+	(*ListSchedulesRequest)(nil),  // 23: recorder.ListSchedulesRequest
+	(*ListSchedulesReply)(nil),    // 24: recorder.ListSchedulesReply
+	(*CancelScheduleRequest)(nil), // 25: recorder.CancelScheduleRequest
+	(*CancelScheduleReply)(nil),   // 26: recorder.CancelScheduleReply
+	(*GetHealthRequest)(nil),      // 27: recorder.GetHealthRequest
+	(*PandoraInstance)(nil),       // 28: recorder.PandoraInstance
+	(*GetHealthReply)(nil),        // 29: recorder.GetHealthReply
+	nil,                           // 30: recorder.RecordingMetadata.LabelsEntry
+	nil,                           // 31: recorder.StopRecordReply.OffsetsEntry
+	nil,                           // 32: recorder.AbortRecordReply.StopFailuresEntry
+	nil,                           // 33: recorder.Recording.OffsetsEntry
+	nil,                           // 34: recorder.Recording.StopFailuresEntry
+}
+var file_proto_recorder_proto_depIdxs = []int32{
+	30, // 0: recorder.RecordingMetadata.labels:type_name -> recorder.RecordingMetadata.LabelsEntry
+	0,  // 1: recorder.StartRecordRequest.metadata:type_name -> recorder.RecordingMetadata
+	31, // 2: recorder.StopRecordReply.offsets:type_name -> recorder.StopRecordReply.OffsetsEntry
+	8,  // 3: recorder.StopRecordReply.pauses:type_name -> recorder.PauseInterval
+	0,  // 4: recorder.StopRecordReply.metadata:type_name -> recorder.RecordingMetadata
+	5,  // 5: recorder.StopRecordReply.mix:type_name -> recorder.MixJob
+	32, // 6: recorder.AbortRecordReply.stopFailures:type_name -> recorder.AbortRecordReply.StopFailuresEntry
+	14, // 7: recorder.GetRecordingReply.discord:type_name -> recorder.SourceStatus
+	14, // 8: recorder.GetRecordingReply.roll20:type_name -> recorder.SourceStatus
+	0,  // 9: recorder.GetRecordingReply.metadata:type_name -> recorder.RecordingMetadata
+	33, // 10: recorder.Recording.offsets:type_name -> recorder.Recording.OffsetsEntry
+	8,  // 11: recorder.Recording.pauses:type_name -> recorder.PauseInterval
+	0,  // 12: recorder.Recording.metadata:type_name -> recorder.RecordingMetadata
+	5,  // 13: recorder.Recording.mix:type_name -> recorder.MixJob
+	34, // 14: recorder.Recording.stopFailures:type_name -> recorder.Recording.StopFailuresEntry
+	17, // 15: recorder.ListRecordingsReply.recordings:type_name -> recorder.Recording
+	0,  // 16: recorder.RecordingEvent.metadata:type_name -> recorder.RecordingMetadata
+	0,  // 17: recorder.ScheduleRecordRequest.metadata:type_name -> recorder.RecordingMetadata
+	0,  // 18: recorder.ScheduledRecording.metadata:type_name -> recorder.RecordingMetadata
+	22, // 19: recorder.ListSchedulesReply.schedules:type_name -> recorder.ScheduledRecording
+	28, // 20: recorder.GetHealthReply.pandoraInstances:type_name -> recorder.PandoraInstance
+	1,  // 21: recorder.RecordService.Start:input_type -> recorder.StartRecordRequest
+	3,  // 22: recorder.RecordService.Stop:input_type -> recorder.StopRecordRequest
+	9,  // 23: recorder.RecordService.Pause:input_type -> recorder.PauseRecordRequest
+	11, // 24: recorder.RecordService.Resume:input_type -> recorder.ResumeRecordRequest
+	13, // 25: recorder.RecordService.GetRecording:input_type -> recorder.GetRecordingRequest
+	16, // 26: recorder.RecordService.ListRecordings:input_type -> recorder.ListRecordingsRequest
+	19, // 27: recorder.RecordService.WatchRecording:input_type -> recorder.WatchRecordingRequest
+	21, // 28: recorder.RecordService.ScheduleRecording:input_type -> recorder.ScheduleRecordRequest
+	23, // 29: recorder.RecordService.ListSchedules:input_type -> recorder.ListSchedulesRequest
+	25, // 30: recorder.RecordService.CancelSchedule:input_type -> recorder.CancelScheduleRequest
+	6,  // 31: recorder.RecordService.Abort:input_type -> recorder.AbortRecordRequest
+	27, // 32: recorder.RecordService.GetHealth:input_type -> recorder.GetHealthRequest
+	2,  // 33: recorder.RecordService.Start:output_type -> recorder.StartRecordReply
+	4,  // 34: recorder.RecordService.Stop:output_type -> recorder.StopRecordReply
+	10, // 35: recorder.RecordService.Pause:output_type -> recorder.PauseRecordReply
+	12, // 36: recorder.RecordService.Resume:output_type -> recorder.ResumeRecordReply
+	15, // 37: recorder.RecordService.GetRecording:output_type -> recorder.GetRecordingReply
+	18, // 38: recorder.RecordService.ListRecordings:output_type -> recorder.ListRecordingsReply
+	20, // 39: recorder.RecordService.WatchRecording:output_type -> recorder.RecordingEvent
+	22, // 40: recorder.RecordService.ScheduleRecording:output_type -> recorder.ScheduledRecording
+	24, // 41: recorder.RecordService.ListSchedules:output_type -> recorder.ListSchedulesReply
+	26, // 42: recorder.RecordService.CancelSchedule:output_type -> recorder.CancelScheduleReply
+	7,  // 43: recorder.RecordService.Abort:output_type -> recorder.AbortRecordReply
+	29, // 44: recorder.RecordService.GetHealth:output_type -> recorder.GetHealthReply
+	33, // [33:45] is the sub-list for method output_type
+	21, // [21:33] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
+}
+
+func init() { file_proto_recorder_proto_init() }
+func file_proto_recorder_proto_init() {
+	if File_proto_recorder_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_recorder_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecordingMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartRecordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartRecordReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopRecordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
 				return nil
 			}
 		}
-		file_proto_recorder_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_recorder_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StopRecordReply); i {
 			case 0:
 				return &v.state
@@ -366,6 +2857,306 @@ func file_proto_recorder_proto_init() {
 				return nil
 			}
 		}
+		file_proto_recorder_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MixJob); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AbortRecordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AbortRecordReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PauseInterval); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PauseRecordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PauseRecordReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResumeRecordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResumeRecordReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRecordingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SourceStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRecordingReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRecordingsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Recording); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRecordingsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchRecordingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecordingEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduleRecordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduledRecording); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSchedulesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSchedulesReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelScheduleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelScheduleReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetHealthRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PandoraInstance); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_recorder_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetHealthReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -373,7 +3164,7 @@ func file_proto_recorder_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_recorder_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
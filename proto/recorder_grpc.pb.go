@@ -24,6 +24,16 @@ const _ = grpc.SupportPackageIsVersion7
 type RecordServiceClient interface {
 	Start(ctx context.Context, in *StartRecordRequest, opts ...grpc.CallOption) (*StartRecordReply, error)
 	Stop(ctx context.Context, in *StopRecordRequest, opts ...grpc.CallOption) (*StopRecordReply, error)
+	Pause(ctx context.Context, in *PauseRecordRequest, opts ...grpc.CallOption) (*PauseRecordReply, error)
+	Resume(ctx context.Context, in *ResumeRecordRequest, opts ...grpc.CallOption) (*ResumeRecordReply, error)
+	GetRecording(ctx context.Context, in *GetRecordingRequest, opts ...grpc.CallOption) (*GetRecordingReply, error)
+	ListRecordings(ctx context.Context, in *ListRecordingsRequest, opts ...grpc.CallOption) (*ListRecordingsReply, error)
+	WatchRecording(ctx context.Context, in *WatchRecordingRequest, opts ...grpc.CallOption) (RecordService_WatchRecordingClient, error)
+	ScheduleRecording(ctx context.Context, in *ScheduleRecordRequest, opts ...grpc.CallOption) (*ScheduledRecording, error)
+	ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesReply, error)
+	CancelSchedule(ctx context.Context, in *CancelScheduleRequest, opts ...grpc.CallOption) (*CancelScheduleReply, error)
+	Abort(ctx context.Context, in *AbortRecordRequest, opts ...grpc.CallOption) (*AbortRecordReply, error)
+	GetHealth(ctx context.Context, in *GetHealthRequest, opts ...grpc.CallOption) (*GetHealthReply, error)
 }
 
 type recordServiceClient struct {
@@ -52,12 +62,135 @@ func (c *recordServiceClient) Stop(ctx context.Context, in *StopRecordRequest, o
 	return out, nil
 }
 
+func (c *recordServiceClient) Pause(ctx context.Context, in *PauseRecordRequest, opts ...grpc.CallOption) (*PauseRecordReply, error) {
+	out := new(PauseRecordReply)
+	err := c.cc.Invoke(ctx, "/recorder.RecordService/Pause", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recordServiceClient) Resume(ctx context.Context, in *ResumeRecordRequest, opts ...grpc.CallOption) (*ResumeRecordReply, error) {
+	out := new(ResumeRecordReply)
+	err := c.cc.Invoke(ctx, "/recorder.RecordService/Resume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recordServiceClient) GetRecording(ctx context.Context, in *GetRecordingRequest, opts ...grpc.CallOption) (*GetRecordingReply, error) {
+	out := new(GetRecordingReply)
+	err := c.cc.Invoke(ctx, "/recorder.RecordService/GetRecording", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recordServiceClient) ListRecordings(ctx context.Context, in *ListRecordingsRequest, opts ...grpc.CallOption) (*ListRecordingsReply, error) {
+	out := new(ListRecordingsReply)
+	err := c.cc.Invoke(ctx, "/recorder.RecordService/ListRecordings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recordServiceClient) WatchRecording(ctx context.Context, in *WatchRecordingRequest, opts ...grpc.CallOption) (RecordService_WatchRecordingClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RecordService_ServiceDesc.Streams[0], "/recorder.RecordService/WatchRecording", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &recordServiceWatchRecordingClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RecordService_WatchRecordingClient interface {
+	Recv() (*RecordingEvent, error)
+	grpc.ClientStream
+}
+
+type recordServiceWatchRecordingClient struct {
+	grpc.ClientStream
+}
+
+func (x *recordServiceWatchRecordingClient) Recv() (*RecordingEvent, error) {
+	m := new(RecordingEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *recordServiceClient) ScheduleRecording(ctx context.Context, in *ScheduleRecordRequest, opts ...grpc.CallOption) (*ScheduledRecording, error) {
+	out := new(ScheduledRecording)
+	err := c.cc.Invoke(ctx, "/recorder.RecordService/ScheduleRecording", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recordServiceClient) ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesReply, error) {
+	out := new(ListSchedulesReply)
+	err := c.cc.Invoke(ctx, "/recorder.RecordService/ListSchedules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recordServiceClient) CancelSchedule(ctx context.Context, in *CancelScheduleRequest, opts ...grpc.CallOption) (*CancelScheduleReply, error) {
+	out := new(CancelScheduleReply)
+	err := c.cc.Invoke(ctx, "/recorder.RecordService/CancelSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recordServiceClient) Abort(ctx context.Context, in *AbortRecordRequest, opts ...grpc.CallOption) (*AbortRecordReply, error) {
+	out := new(AbortRecordReply)
+	err := c.cc.Invoke(ctx, "/recorder.RecordService/Abort", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recordServiceClient) GetHealth(ctx context.Context, in *GetHealthRequest, opts ...grpc.CallOption) (*GetHealthReply, error) {
+	out := new(GetHealthReply)
+	err := c.cc.Invoke(ctx, "/recorder.RecordService/GetHealth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RecordServiceServer is the server API for RecordService service.
 // All implementations must embed UnimplementedRecordServiceServer
 // for forward compatibility
 type RecordServiceServer interface {
 	Start(context.Context, *StartRecordRequest) (*StartRecordReply, error)
 	Stop(context.Context, *StopRecordRequest) (*StopRecordReply, error)
+	Pause(context.Context, *PauseRecordRequest) (*PauseRecordReply, error)
+	Resume(context.Context, *ResumeRecordRequest) (*ResumeRecordReply, error)
+	GetRecording(context.Context, *GetRecordingRequest) (*GetRecordingReply, error)
+	ListRecordings(context.Context, *ListRecordingsRequest) (*ListRecordingsReply, error)
+	WatchRecording(*WatchRecordingRequest, RecordService_WatchRecordingServer) error
+	ScheduleRecording(context.Context, *ScheduleRecordRequest) (*ScheduledRecording, error)
+	ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesReply, error)
+	CancelSchedule(context.Context, *CancelScheduleRequest) (*CancelScheduleReply, error)
+	Abort(context.Context, *AbortRecordRequest) (*AbortRecordReply, error)
+	GetHealth(context.Context, *GetHealthRequest) (*GetHealthReply, error)
 	mustEmbedUnimplementedRecordServiceServer()
 }
 
@@ -71,6 +204,36 @@ func (UnimplementedRecordServiceServer) Start(context.Context, *StartRecordReque
 func (UnimplementedRecordServiceServer) Stop(context.Context, *StopRecordRequest) (*StopRecordReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
 }
+func (UnimplementedRecordServiceServer) Pause(context.Context, *PauseRecordRequest) (*PauseRecordReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pause not implemented")
+}
+func (UnimplementedRecordServiceServer) Resume(context.Context, *ResumeRecordRequest) (*ResumeRecordReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resume not implemented")
+}
+func (UnimplementedRecordServiceServer) GetRecording(context.Context, *GetRecordingRequest) (*GetRecordingReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecording not implemented")
+}
+func (UnimplementedRecordServiceServer) ListRecordings(context.Context, *ListRecordingsRequest) (*ListRecordingsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecordings not implemented")
+}
+func (UnimplementedRecordServiceServer) WatchRecording(*WatchRecordingRequest, RecordService_WatchRecordingServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchRecording not implemented")
+}
+func (UnimplementedRecordServiceServer) ScheduleRecording(context.Context, *ScheduleRecordRequest) (*ScheduledRecording, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScheduleRecording not implemented")
+}
+func (UnimplementedRecordServiceServer) ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSchedules not implemented")
+}
+func (UnimplementedRecordServiceServer) CancelSchedule(context.Context, *CancelScheduleRequest) (*CancelScheduleReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelSchedule not implemented")
+}
+func (UnimplementedRecordServiceServer) Abort(context.Context, *AbortRecordRequest) (*AbortRecordReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Abort not implemented")
+}
+func (UnimplementedRecordServiceServer) GetHealth(context.Context, *GetHealthRequest) (*GetHealthReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHealth not implemented")
+}
 func (UnimplementedRecordServiceServer) mustEmbedUnimplementedRecordServiceServer() {}
 
 // UnsafeRecordServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -120,6 +283,189 @@ func _RecordService_Stop_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RecordService_Pause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecordServiceServer).Pause(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/recorder.RecordService/Pause",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecordServiceServer).Pause(ctx, req.(*PauseRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecordService_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecordServiceServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/recorder.RecordService/Resume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecordServiceServer).Resume(ctx, req.(*ResumeRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecordService_GetRecording_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecordingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecordServiceServer).GetRecording(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/recorder.RecordService/GetRecording",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecordServiceServer).GetRecording(ctx, req.(*GetRecordingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecordService_ListRecordings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecordingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecordServiceServer).ListRecordings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/recorder.RecordService/ListRecordings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecordServiceServer).ListRecordings(ctx, req.(*ListRecordingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecordService_WatchRecording_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRecordingRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RecordServiceServer).WatchRecording(m, &recordServiceWatchRecordingServer{stream})
+}
+
+type RecordService_WatchRecordingServer interface {
+	Send(*RecordingEvent) error
+	grpc.ServerStream
+}
+
+type recordServiceWatchRecordingServer struct {
+	grpc.ServerStream
+}
+
+func (x *recordServiceWatchRecordingServer) Send(m *RecordingEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RecordService_ScheduleRecording_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecordServiceServer).ScheduleRecording(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/recorder.RecordService/ScheduleRecording",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecordServiceServer).ScheduleRecording(ctx, req.(*ScheduleRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecordService_ListSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecordServiceServer).ListSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/recorder.RecordService/ListSchedules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecordServiceServer).ListSchedules(ctx, req.(*ListSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecordService_CancelSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecordServiceServer).CancelSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/recorder.RecordService/CancelSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecordServiceServer).CancelSchedule(ctx, req.(*CancelScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecordService_Abort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbortRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecordServiceServer).Abort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/recorder.RecordService/Abort",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecordServiceServer).Abort(ctx, req.(*AbortRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecordService_GetHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecordServiceServer).GetHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/recorder.RecordService/GetHealth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecordServiceServer).GetHealth(ctx, req.(*GetHealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RecordService_ServiceDesc is the grpc.ServiceDesc for RecordService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -135,7 +481,49 @@ var RecordService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Stop",
 			Handler:    _RecordService_Stop_Handler,
 		},
+		{
+			MethodName: "Pause",
+			Handler:    _RecordService_Pause_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _RecordService_Resume_Handler,
+		},
+		{
+			MethodName: "GetRecording",
+			Handler:    _RecordService_GetRecording_Handler,
+		},
+		{
+			MethodName: "ListRecordings",
+			Handler:    _RecordService_ListRecordings_Handler,
+		},
+		{
+			MethodName: "ScheduleRecording",
+			Handler:    _RecordService_ScheduleRecording_Handler,
+		},
+		{
+			MethodName: "ListSchedules",
+			Handler:    _RecordService_ListSchedules_Handler,
+		},
+		{
+			MethodName: "CancelSchedule",
+			Handler:    _RecordService_CancelSchedule_Handler,
+		},
+		{
+			MethodName: "Abort",
+			Handler:    _RecordService_Abort_Handler,
+		},
+		{
+			MethodName: "GetHealth",
+			Handler:    _RecordService_GetHealth_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchRecording",
+			Handler:       _RecordService_WatchRecording_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/recorder.proto",
 }
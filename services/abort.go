@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"record-orchestrator/pkg/events"
+	"record-orchestrator/pkg/memory"
+	pb "record-orchestrator/proto"
+)
+
+// Reason of an abort, if none was given
+const DEFAULT_ABORT_REASON = "aborted by an administrator"
+
+// Abort forcibly ends a session, typically one stuck because a source crashed and cannot be stopped anymore.
+// Every source is asked to stop, but the session is cleared whatever they reply, and kept in the history
+// along with the reason of the abort and the stops that failed
+func (r *Recorder) Abort(ctx context.Context, payload *pb.AbortRecordRequest) (*pb.AbortRecordReply, error) {
+	key, err := r.locate(ctx, payload.GetSessionId(), payload.GetVoiceChannelId())
+	if err != nil {
+		return nil, err
+	}
+	defer r.lock(key)()
+
+	state, err := r.memory.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil || (payload.GetSessionId() != "" && state.Id != payload.GetSessionId()) {
+		return nil, fmt.Errorf("%w to abort on voice channel %s", ErrNoSession, key)
+	}
+	// A session that already ended only left its state behind, as it could not be deleted.
+	// Its sources are done and it was already kept in the history if it needed to
+	if !state.IsActive() {
+		slog.Warn(fmt.Sprintf("[Recorder] :: Clearing the leftover state of %s session %s", state.Phase, state.Id))
+		return &pb.AbortRecordReply{}, r.memory.Delete(context.WithoutCancel(ctx), key)
+	}
+	r.unwatch(state.VcId)
+	state.AbortReason = payload.GetReason()
+	if state.AbortReason == "" {
+		state.AbortReason = DEFAULT_ABORT_REASON
+	}
+	slog.Warn(fmt.Sprintf("[Recorder] :: Aborting session %s of voice channel %s : %s", state.Id, state.VcId, state.AbortReason))
+
+	state.StopFailures = make(map[string]string)
+	ids := state.DiscordKeys
+	if state.Discord.Status != memory.SourceInterrupted {
+		if ids, err = r.pandora.Stop(ctx, state.PandoraInstance, state.VcId); err != nil {
+			state.StopFailures[SourceDiscord] = err.Error()
+			state.Discord.SetStatus(memory.SourceFailed, err)
+		} else {
+			state.Discord.SetStatus(memory.SourceStopped, nil)
+		}
+	}
+	r20Key := ""
+	if state.R20Id != "" {
+		if r20Key, err = r.roll20Sync.Stop(ctx, state.R20Id); err != nil {
+			state.StopFailures[SourceRoll20] = err.Error()
+			state.Roll20.SetStatus(memory.SourceFailed, err)
+		} else {
+			state.Roll20.SetStatus(memory.SourceStopped, nil)
+		}
+	}
+
+	// The session is cleared even if the caller gave up while the sources were stopping
+	ctx = context.WithoutCancel(ctx)
+	if err = state.Transition(memory.Failed); err != nil {
+		return nil, err
+	}
+	endedAt, _ := state.EnteredAt(memory.Failed)
+	reply := &pb.AbortRecordReply{
+		DiscordKeys:  ids,
+		Roll20Key:    r20Key,
+		StopFailures: state.StopFailures,
+	}
+	r.archive(ctx, state, &pb.StopRecordReply{
+		DiscordKeys: ids,
+		Roll20Key:   r20Key,
+		Offsets:     trackOffsets(state.Offsets, ids, r20Key),
+		Pauses:      toPauseIntervals(pauseOffsets(state, endedAt)),
+	})
+	if err = r.memory.Delete(ctx, key); err != nil {
+		return nil, err
+	}
+	aborted := newEvent(events.Aborted, state, state.AbortReason)
+	aborted.DiscordKeys, aborted.Roll20Key = ids, r20Key
+	r.emit(aborted)
+	return reply, nil
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"record-orchestrator/pkg/events"
+	"record-orchestrator/pkg/memory"
+	pb "record-orchestrator/proto"
+	test_utils "record-orchestrator/test-utils"
+	"testing"
+)
+
+// Pandora crashed, its stop times out
+func TestRecorder_AbortStuckSession(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	history := test_utils.MockHistoryStore{}
+	evts := test_utils.MockEmitter{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{History: &history, Events: &evts})
+	state := recordingState("1", "2")
+	mem.EXPECT().Keys(mock.Anything).Return([]string{"1"}, nil)
+	mem.EXPECT().Get(mock.Anything, "1").Return(state, nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil).Once()
+	pandora.On("Stop", mock.Anything, "", "1").Return([]string{}, assert.AnError)
+	r20Rec.On("Stop", mock.Anything, "2").Return("2.ogg", nil)
+	var record memory.Record
+	history.EXPECT().Save(mock.Anything, mock.Anything, mock.Anything).Run(func(ctx context.Context, key string, value memory.Record) {
+		record = value
+	}).Return(nil).Once()
+	evts.EXPECT().Emit(mock.MatchedBy(func(e events.Event) bool {
+		return e.Kind == events.Aborted && e.Message == "pandora crashed" && e.Roll20Key == "2.ogg"
+	})).Return(nil).Once()
+
+	reply, err := recorder.Abort(context.Background(), &pb.AbortRecordRequest{SessionId: state.Id, Reason: "pandora crashed"})
+	assert.NoError(t, err)
+	assert.Equal(t, "2.ogg", reply.Roll20Key)
+	assert.Equal(t, map[string]string{SourceDiscord: assert.AnError.Error()}, reply.StopFailures)
+	assert.True(t, record.Aborted)
+	assert.Equal(t, "pandora crashed", record.AbortReason)
+	assert.Equal(t, reply.StopFailures, record.StopFailures)
+	assert.Equal(t, "2.ogg", record.R20Key)
+	mem.AssertExpectations(t)
+	evts.AssertExpectations(t)
+}
+
+func TestRecorder_AbortDefaultReason(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	history := test_utils.MockHistoryStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{History: &history})
+	mem.EXPECT().Get(mock.Anything, "1").Return(recordingState("1", ""), nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil)
+	pandora.On("Stop", mock.Anything, "", "1").Return([]string{"a"}, nil)
+	history.EXPECT().Save(mock.Anything, mock.Anything, mock.MatchedBy(func(record memory.Record) bool {
+		return record.AbortReason == DEFAULT_ABORT_REASON && len(record.StopFailures) == 0 && record.DiscordKeys[0] == "a"
+	})).Return(nil).Once()
+
+	reply, err := recorder.Abort(context.Background(), &pb.AbortRecordRequest{VoiceChannelId: "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a"}, reply.DiscordKeys)
+	r20Rec.AssertNotCalled(t, "Stop", mock.Anything, mock.Anything)
+	history.AssertExpectations(t)
+}
+
+// The session ended, but its state could not be deleted
+func TestRecorder_AbortLeftover(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, nil, &mem, RecorderOpt{})
+	state := recordingState("1", "")
+	_ = state.Transition(memory.Stopping)
+	_ = state.Transition(memory.Stopped)
+	mem.EXPECT().Get(mock.Anything, "1").Return(state, nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil).Once()
+
+	_, err := recorder.Abort(context.Background(), &pb.AbortRecordRequest{VoiceChannelId: "1"})
+	assert.NoError(t, err)
+	pandora.AssertNotCalled(t, "Stop", mock.Anything, mock.Anything, mock.Anything)
+	mem.AssertExpectations(t)
+}
+
+func TestRecorder_AbortUnknownSession(t *testing.T) {
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(nil, nil, &mem, RecorderOpt{})
+	mem.EXPECT().Get(mock.Anything, "1").Return(nil, nil)
+
+	_, err := recorder.Abort(context.Background(), &pb.AbortRecordRequest{VoiceChannelId: "1"})
+	assert.ErrorIs(t, err, ErrNoSession)
+	mem.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
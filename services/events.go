@@ -0,0 +1,57 @@
+package services
+
+import (
+	"fmt"
+	"log/slog"
+	"record-orchestrator/pkg/events"
+	"record-orchestrator/pkg/memory"
+	"time"
+)
+
+// Kinds of events also published on the pubsub component, for the downstream services.
+// Every event is dispatched to the in-process watchers
+var published = map[events.Kind]bool{
+	events.Started:          true,
+	events.Stopped:          true,
+	events.PartiallyStopped: true,
+	events.Failed:           true,
+	events.Aborted:          true,
+	events.Interrupted:      true,
+	events.Reconciled:       true,
+}
+
+func newEvent(kind events.Kind, state *memory.State, message string) events.Event {
+	return events.Event{
+		Kind:           kind,
+		SessionId:      state.Id,
+		VoiceChannelId: state.VcId,
+		Roll20GameId:   state.R20Id,
+		Phase:          string(state.Phase),
+		Message:        message,
+		Metadata:       toEventMetadata(state.Metadata),
+		At:             time.Now(),
+	}
+}
+
+// Dispatch a lifecycle event. Failing to publish it never fails the operation
+// that triggered it
+func (r *Recorder) emit(e events.Event) {
+	_ = r.watchers.Emit(e)
+	if r.webhooks != nil {
+		if err := r.webhooks.Emit(e); err != nil {
+			slog.Warn(fmt.Sprintf("[Recorder] :: Could not notify the webhooks of event %s of session %s : %s", e.Kind, e.VoiceChannelId, err.Error()))
+		}
+	}
+	if !published[e.Kind] {
+		return
+	}
+	if err := r.events.Emit(e); err != nil {
+		slog.Warn(fmt.Sprintf("[Recorder] :: Could not publish event %s of session %s : %s", e.Kind, e.VoiceChannelId, err.Error()))
+	}
+}
+
+// Watch the lifecycle events of the sessions recording vcId, or of every session if empty.
+// The returned function stops watching
+func (r *Recorder) Watch(vcId string) (<-chan events.Event, func()) {
+	return r.watchers.Subscribe(vcId)
+}
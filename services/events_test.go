@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"record-orchestrator/pkg/events"
+	"record-orchestrator/pkg/memory"
+	pb "record-orchestrator/proto"
+	test_utils "record-orchestrator/test-utils"
+	"testing"
+)
+
+// Drain every event already dispatched to a watcher
+func drain(ch <-chan events.Event) []events.Kind {
+	var kinds []events.Kind
+	for {
+		select {
+		case e := <-ch:
+			kinds = append(kinds, e.Kind)
+		default:
+			return kinds
+		}
+	}
+}
+
+func TestRecorder_WatchLifecycle(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	watched, cancel := recorder.Watch("1")
+	defer cancel()
+	other, cancelOther := recorder.Watch("3")
+	defer cancelOther()
+
+	pandora.On("Start", mock.Anything, "1").Return("", nil)
+	r20Rec.On("Start", mock.Anything, "2").Return(nil)
+	mem.EXPECT().Get(mock.Anything, "1").Return(nil, nil).Once()
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Return(nil)
+	_, err := recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: "1", Roll20GameId: "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, []events.Kind{events.StartRequested, events.DiscordAcknowledged, events.Roll20Attached, events.Started}, drain(watched))
+
+	mem.EXPECT().Get(mock.Anything, "1").Return(recordingState("1", "2"), nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil)
+	pandora.On("Stop", mock.Anything, "", "1").Return([]string{"a"}, nil)
+	r20Rec.On("Stop", mock.Anything, "2").Return("", assert.AnError)
+	_, err = recorder.Stop(context.Background(), &pb.StopRecordRequest{VoiceChannelId: "1", Roll20GameId: "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, []events.Kind{events.StopRequested, events.TracksUploaded, events.Warning, events.PartiallyStopped}, drain(watched))
+
+	assert.Empty(t, drain(other))
+}
+
+func TestRecorder_PublishesLifecycle(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	evts := test_utils.MockEmitter{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{Events: &evts})
+	var state *memory.State
+	mem.EXPECT().Get(mock.Anything, "1").RunAndReturn(func(context.Context, string) (*memory.State, error) {
+		return state, nil
+	})
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Run(func(ctx context.Context, key string, value memory.State) {
+		state = &value
+	}).Return(nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil)
+	pandora.On("Start", mock.Anything, "1").Return("", nil)
+	pandora.On("Stop", mock.Anything, "", "1").Return([]string{"a"}, nil)
+	r20Rec.On("Start", mock.Anything, "2").Return(nil)
+	r20Rec.On("Stop", mock.Anything, "2").Return("2.ogg", nil)
+	var published []events.Event
+	evts.EXPECT().Emit(mock.Anything).Run(func(e events.Event) {
+		published = append(published, e)
+	}).Return(nil)
+
+	ret, err := recorder.Start(context.Background(), &pb.StartRecordRequest{
+		VoiceChannelId: "1",
+		Roll20GameId:   "2",
+		Metadata:       &pb.RecordingMetadata{Campaign: "X"},
+	})
+	assert.NoError(t, err)
+	mem.EXPECT().Keys(mock.Anything).Return([]string{"1"}, nil)
+	_, err = recorder.Stop(context.Background(), &pb.StopRecordRequest{SessionId: ret.SessionId})
+	assert.NoError(t, err)
+
+	// Only the domain events are published
+	assert.Len(t, published, 2)
+	started, stopped := published[0], published[1]
+	assert.Equal(t, events.Started, started.Kind)
+	assert.Equal(t, ret.SessionId, started.SessionId)
+	assert.Contains(t, started.Offsets, SourceRoll20)
+	assert.Equal(t, events.Stopped, stopped.Kind)
+	assert.Equal(t, ret.SessionId, stopped.SessionId)
+	assert.Equal(t, []string{"a"}, stopped.DiscordKeys)
+	assert.Equal(t, "2.ogg", stopped.Roll20Key)
+	assert.Contains(t, stopped.Offsets, "2.ogg")
+	assert.Equal(t, "X", stopped.Metadata.Campaign)
+}
+
+func TestRecorder_PublishesPartialStop(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	evts := test_utils.MockEmitter{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{Events: &evts})
+	// Roll20 failed at some point during the session
+	state := recordingState("1", "")
+	state.Roll20.SetStatus(memory.SourceFailed, assert.AnError)
+	mem.EXPECT().Get(mock.Anything, "1").Return(state, nil)
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Return(nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil)
+	pandora.On("Stop", mock.Anything, "", "1").Return([]string{"a"}, nil)
+	evts.EXPECT().Emit(mock.MatchedBy(func(e events.Event) bool {
+		return e.Kind == events.PartiallyStopped && e.DiscordKeys[0] == "a" && e.Message != ""
+	})).Return(nil).Once()
+
+	_, err := recorder.Stop(context.Background(), &pb.StopRecordRequest{VoiceChannelId: "1"})
+	assert.NoError(t, err)
+	evts.AssertExpectations(t)
+}
+
+func TestRecorder_PublishesFailedStart(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	evts := test_utils.MockEmitter{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{Events: &evts})
+	mem.EXPECT().Get(mock.Anything, "1").Return(nil, nil)
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Return(nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil)
+	pandora.On("Start", mock.Anything, "1").Return("", assert.AnError)
+	evts.EXPECT().Emit(mock.MatchedBy(func(e events.Event) bool {
+		return e.Kind == events.Failed && e.SessionId != ""
+	})).Return(nil).Once()
+
+	_, err := recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: "1"})
+	assert.Error(t, err)
+	evts.AssertExpectations(t)
+}
+
+func TestRecorder_NotifiesWebhooks(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	hooks := test_utils.MockEmitter{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{Webhooks: &hooks})
+	mem.EXPECT().Get(mock.Anything, "1").Return(nil, nil)
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Return(nil)
+	pandora.On("Start", mock.Anything, "1").Return("", nil)
+	var notified []events.Kind
+	// A failing webhook doesn't fail the session
+	hooks.EXPECT().Emit(mock.Anything).Run(func(e events.Event) {
+		notified = append(notified, e.Kind)
+	}).Return(assert.AnError)
+
+	_, err := recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: "1"})
+	assert.NoError(t, err)
+	// Webhooks aren't limited to the published events
+	assert.Equal(t, []events.Kind{events.StartRequested, events.DiscordAcknowledged, events.Started}, notified)
+}
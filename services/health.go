@@ -0,0 +1,30 @@
+package services
+
+import (
+	"context"
+	pb "record-orchestrator/proto"
+)
+
+// GetHealth tells whether the sources can be reached. Pandora is only known
+// to be down once it sent heartbeats and stopped
+func (r *Recorder) GetHealth(ctx context.Context, payload *pb.GetHealthRequest) (*pb.GetHealthReply, error) {
+	l := r.pandora.Liveness()
+	reply := &pb.GetHealthReply{
+		Pandora:              string(l.Status),
+		PandoraInstances:     make([]*pb.PandoraInstance, 0, len(l.Instances)),
+		PandoraSchemaVersion: int32(l.SchemaVersion),
+	}
+	if !l.LastSeen.IsZero() {
+		reply.PandoraLastSeen = l.LastSeen.UnixMilli()
+	}
+	for _, instance := range l.Instances {
+		reply.PandoraInstances = append(reply.PandoraInstances, &pb.PandoraInstance{
+			Id:             instance.Id,
+			Version:        instance.Version,
+			VoiceChannelId: instance.VoiceChannelId,
+			LastSeen:       instance.LastSeen.UnixMilli(),
+			SchemaVersion:  int32(instance.SchemaVersion),
+		})
+	}
+	return reply, nil
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"record-orchestrator/pkg/pandora"
+	pb "record-orchestrator/proto"
+	test_utils "record-orchestrator/test-utils"
+	"testing"
+	"time"
+)
+
+func TestRecorder_GetHealth(t *testing.T) {
+	pandoraRec := test_utils.MockDiscordRecorder{}
+	recorder := NewRecorder(&pandoraRec, nil, nil, RecorderOpt{})
+	seen := time.UnixMilli(1700000000000)
+	pandoraRec.EXPECT().Liveness().Return(pandora.Liveness{
+		Status:        pandora.LivenessUp,
+		LastSeen:      seen,
+		Instances:     []pandora.Instance{{Id: "a", Version: "2.0.0", SchemaVersion: 1, VoiceChannelId: "1", LastSeen: seen}},
+		SchemaVersion: 1,
+	})
+
+	reply, err := recorder.GetHealth(context.Background(), &pb.GetHealthRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, "up", reply.Pandora)
+	assert.Equal(t, int64(1700000000000), reply.PandoraLastSeen)
+	assert.Len(t, reply.PandoraInstances, 1)
+	assert.Equal(t, "a", reply.PandoraInstances[0].Id)
+	assert.Equal(t, "1", reply.PandoraInstances[0].VoiceChannelId)
+	assert.Equal(t, int32(1), reply.PandoraInstances[0].SchemaVersion)
+	assert.Equal(t, int32(1), reply.PandoraSchemaVersion)
+}
+
+// No heartbeat was ever received
+func TestRecorder_GetHealthUnknown(t *testing.T) {
+	pandoraRec := test_utils.MockDiscordRecorder{}
+	recorder := NewRecorder(&pandoraRec, nil, nil, RecorderOpt{})
+	pandoraRec.EXPECT().Liveness().Return(pandora.Liveness{Status: pandora.LivenessUnknown})
+
+	reply, err := recorder.GetHealth(context.Background(), &pb.GetHealthRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, "unknown", reply.Pandora)
+	assert.Zero(t, reply.PandoraLastSeen)
+	assert.Empty(t, reply.PandoraInstances)
+}
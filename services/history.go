@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"record-orchestrator/pkg/memory"
+	pb "record-orchestrator/proto"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DEFAULT_PAGE_SIZE = 20
+	MAX_PAGE_SIZE     = 100
+	// Records read at most by a single listing, which returns a short page once reached
+	MAX_SCANNED_RECORDS = 500
+)
+
+// Keep an ended session in the history, along with the keys it produced, returning its id.
+// The recording itself is already over, so a failure is only logged
+func (r *Recorder) archive(ctx context.Context, state *memory.State, reply *pb.StopRecordReply) string {
+	startedAt := state.StartedAt()
+	id := fmt.Sprintf("%s-%d", state.VcId, startedAt.UnixMilli())
+	if r.history == nil {
+		return id
+	}
+	stoppedAt, _ := state.EnteredAt(state.Phase)
+	pauses := make([]memory.PauseOffset, 0, len(reply.Pauses))
+	for _, p := range reply.Pauses {
+		pauses = append(pauses, memory.PauseOffset{Start: p.Start, End: p.End})
+	}
+	record := memory.Record{
+		Id:           id,
+		SessionId:    state.Id,
+		VcId:         state.VcId,
+		R20Id:        state.R20Id,
+		DiscordKeys:  reply.DiscordKeys,
+		R20Key:       reply.Roll20Key,
+		Offsets:      reply.Offsets,
+		StartedAt:    startedAt,
+		StoppedAt:    stoppedAt,
+		Requester:    state.Requester,
+		Pauses:       pauses,
+		AutoStopped:  state.AutoStopped,
+		Metadata:     state.Metadata,
+		Mix:          state.Mix,
+		Aborted:      state.AbortReason != "",
+		AbortReason:  state.AbortReason,
+		StopFailures: state.StopFailures,
+	}
+	if err := r.history.Save(ctx, record.Id, record); err != nil {
+		slog.Error(fmt.Sprintf("[Recorder] :: Could not save session %+v in history : %s", record, err.Error()))
+	}
+	return id
+}
+
+// ListRecordings returns the ended sessions matching the request filters, most recent first.
+// Records are ordered by the start time their key carries, so that only the ones that may
+// end up in the page are read, up to MAX_SCANNED_RECORDS per call
+func (r *Recorder) ListRecordings(ctx context.Context, payload *pb.ListRecordingsRequest) (*pb.ListRecordingsReply, error) {
+	if r.history == nil {
+		return nil, fmt.Errorf("[Recorder] :: recordings history is disabled")
+	}
+	pageSize := int(payload.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = DEFAULT_PAGE_SIZE
+	}
+	pageSize = min(pageSize, MAX_PAGE_SIZE)
+	var after *cursor
+	if payload.GetPageToken() != "" {
+		c, err := decodeCursor(payload.GetPageToken())
+		if err != nil {
+			return nil, fmt.Errorf("[Recorder] :: %w, invalid page token %s", ErrInvalidArgument, payload.GetPageToken())
+		}
+		after = &c
+	}
+
+	keys, err := r.history.Keys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Only the keys coming after the cursor, and whose voice channel and start time match, are worth reading
+	candidates := make([]cursor, 0, len(keys))
+	for _, key := range keys {
+		c := cursorOf(key)
+		if (after == nil || c.before(*after)) && c.matches(payload) {
+			candidates = append(candidates, c)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[j].before(candidates[i])
+	})
+
+	reply := &pb.ListRecordingsReply{Recordings: make([]*pb.Recording, 0, pageSize)}
+	scanned := 0
+	for _, c := range candidates {
+		// The page may come back short, the next one resumes where the scan stopped
+		if len(reply.Recordings) == pageSize || scanned == MAX_SCANNED_RECORDS {
+			reply.NextPageToken = candidates[scanned-1].encode()
+			break
+		}
+		scanned++
+		record, err := r.history.Get(ctx, c.id)
+		if err != nil {
+			return nil, err
+		}
+		if record != nil && matches(record, payload) {
+			reply.Recordings = append(reply.Recordings, toRecording(record))
+		}
+	}
+	return reply, nil
+}
+
+// PruneHistory removes the recordings started before the history retention
+func (r *Recorder) PruneHistory(ctx context.Context) error {
+	if r.history == nil || r.historyRetention <= 0 {
+		return nil
+	}
+	keys, err := r.history.Keys(ctx)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, key := range keys {
+		c := cursorOf(key)
+		if c.startedAt.IsZero() || time.Since(c.startedAt) <= r.historyRetention {
+			continue
+		}
+		if err := r.history.Delete(ctx, key); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Position of a record in the history, most recent first
+type cursor struct {
+	startedAt time.Time
+	id        string
+}
+
+// Records are kept under the id archive gives them, the voice channel followed by the start time.
+// A key that doesn't follow this format has a zero start time, and is ordered last
+func cursorOf(key string) cursor {
+	c := cursor{id: key}
+	if i := strings.LastIndex(key, "-"); i >= 0 {
+		if millis, err := strconv.ParseInt(key[i+1:], 10, 64); err == nil {
+			c.startedAt = time.UnixMilli(millis)
+		}
+	}
+	return c
+}
+
+// Whether the record at c comes before other in the history, ties broken by id
+func (c cursor) before(other cursor) bool {
+	if !c.startedAt.Equal(other.startedAt) {
+		return c.startedAt.Before(other.startedAt)
+	}
+	return c.id < other.id
+}
+
+// The filters that can be checked without reading the record. A key without
+// start time is read anyway, as the record is the only one knowing it
+func (c cursor) matches(filter *pb.ListRecordingsRequest) bool {
+	if c.startedAt.IsZero() {
+		return true
+	}
+	if filter.GetVoiceChannelId() != "" && c.id != fmt.Sprintf("%s-%d", filter.GetVoiceChannelId(), c.startedAt.UnixMilli()) {
+		return false
+	}
+	if filter.GetFrom() != 0 && c.startedAt.Before(time.UnixMilli(filter.GetFrom())) {
+		return false
+	}
+	if filter.GetTo() != 0 && c.startedAt.After(time.UnixMilli(filter.GetTo())) {
+		return false
+	}
+	return true
+}
+
+// Page tokens are the start time and id of the last record scanned, so that
+// recordings added meanwhile don't shift the next pages
+func (c cursor) encode() string {
+	millis := int64(0)
+	if !c.startedAt.IsZero() {
+		millis = c.startedAt.UnixMilli()
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%s", millis, c.id)))
+}
+
+func decodeCursor(token string) (cursor, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor{}, err
+	}
+	millis, id, found := strings.Cut(string(bytes), ":")
+	if !found || id == "" {
+		return cursor{}, fmt.Errorf("[Recorder] :: malformed page token")
+	}
+	c := cursor{id: id}
+	if millis != "0" {
+		ms, err := strconv.ParseInt(millis, 10, 64)
+		if err != nil {
+			return cursor{}, err
+		}
+		c.startedAt = time.UnixMilli(ms)
+	}
+	return c, nil
+}
+
+func matches(record *memory.Record, filter *pb.ListRecordingsRequest) bool {
+	if filter.GetVoiceChannelId() != "" && record.VcId != filter.GetVoiceChannelId() {
+		return false
+	}
+	if filter.GetRoll20GameId() != "" && record.R20Id != filter.GetRoll20GameId() {
+		return false
+	}
+	if filter.GetCampaign() != "" && record.Metadata.Campaign != filter.GetCampaign() {
+		return false
+	}
+	if filter.GetFrom() != 0 && record.StartedAt.Before(time.UnixMilli(filter.GetFrom())) {
+		return false
+	}
+	if filter.GetTo() != 0 && record.StartedAt.After(time.UnixMilli(filter.GetTo())) {
+		return false
+	}
+	return true
+}
+
+func toRecording(record *memory.Record) *pb.Recording {
+	return &pb.Recording{
+		Id:             record.Id,
+		VoiceChannelId: record.VcId,
+		Roll20GameId:   record.R20Id,
+		DiscordKeys:    record.DiscordKeys,
+		Roll20Key:      record.R20Key,
+		Offsets:        record.Offsets,
+		StartedAt:      record.StartedAt.UnixMilli(),
+		StoppedAt:      record.StoppedAt.UnixMilli(),
+		Requester:      record.Requester,
+		Pauses:         toPauseIntervals(record.Pauses),
+		AutoStopped:    record.AutoStopped,
+		SessionId:      record.SessionId,
+		Metadata:       toPbMetadata(record.Metadata),
+		Mix:            toPbMix(record.Mix),
+		Aborted:        record.Aborted,
+		AbortReason:    record.AbortReason,
+		StopFailures:   record.StopFailures,
+	}
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"record-orchestrator/pkg/memory"
+	pb "record-orchestrator/proto"
+	test_utils "record-orchestrator/test-utils"
+	"testing"
+	"time"
+)
+
+func TestRecorder_StopArchivesSession(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	history := test_utils.MockHistoryStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{History: &history})
+	state := recordingState("1", "2")
+	state.Requester = "gm"
+	state.Offsets = map[string]int64{SourceDiscord: 0, SourceRoll20: 300}
+	mem.EXPECT().Get(mock.Anything, "1").Return(state, nil)
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Return(nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil)
+	pandora.On("Stop", mock.Anything, "", "1").Return([]string{"a"}, nil)
+	r20Rec.On("Stop", mock.Anything, "2").Return("2.ogg", nil)
+	history.EXPECT().Save(mock.Anything, mock.Anything, mock.MatchedBy(func(r memory.Record) bool {
+		return r.VcId == "1" && r.R20Key == "2.ogg" && r.Requester == "gm" &&
+			r.Offsets["2.ogg"] == 300 && !r.StoppedAt.Before(r.StartedAt)
+	})).Return(nil)
+
+	_, err := recorder.Stop(context.Background(), &pb.StopRecordRequest{VoiceChannelId: "1", Roll20GameId: "2"})
+	assert.NoError(t, err)
+	history.AssertExpectations(t)
+}
+
+func TestRecorder_ListRecordings(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	history := test_utils.MockHistoryStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{History: &history})
+	t0 := time.Now().Truncate(time.Millisecond)
+	record := func(vcId string, r20Id string, startedAt time.Time) *memory.Record {
+		return &memory.Record{Id: fmt.Sprintf("%s-%d", vcId, startedAt.UnixMilli()), VcId: vcId, R20Id: r20Id, StartedAt: startedAt}
+	}
+	a := record("1", "", t0.Add(-3*time.Hour))
+	b := record("1", "2", t0.Add(-2*time.Hour))
+	c := record("1", "", t0.Add(-1*time.Hour))
+	d := record("3", "", t0)
+	history.EXPECT().Keys(mock.Anything).Return([]string{a.Id, b.Id, c.Id, d.Id}, nil).Once()
+	for _, r := range []*memory.Record{a, b, c, d} {
+		history.EXPECT().Get(mock.Anything, r.Id).Return(r, nil)
+	}
+
+	// Most recent first, two by two
+	ret, err := recorder.ListRecordings(context.Background(), &pb.ListRecordingsRequest{VoiceChannelId: "1", PageSize: 2})
+	assert.NoError(t, err)
+	assert.Len(t, ret.Recordings, 2)
+	assert.Equal(t, c.Id, ret.Recordings[0].Id)
+	assert.Equal(t, b.Id, ret.Recordings[1].Id)
+	assert.NotEmpty(t, ret.NextPageToken)
+
+	// A recording stopped meanwhile doesn't shift the next page
+	e := record("1", "", t0.Add(time.Hour))
+	history.EXPECT().Keys(mock.Anything).Return([]string{a.Id, b.Id, c.Id, d.Id, e.Id}, nil)
+	history.EXPECT().Get(mock.Anything, e.Id).Return(e, nil)
+	ret, err = recorder.ListRecordings(context.Background(), &pb.ListRecordingsRequest{VoiceChannelId: "1", PageSize: 2, PageToken: ret.NextPageToken})
+	assert.NoError(t, err)
+	assert.Len(t, ret.Recordings, 1)
+	assert.Equal(t, a.Id, ret.Recordings[0].Id)
+	assert.Empty(t, ret.NextPageToken)
+	// Records of other voice channels aren't even read
+	history.AssertNotCalled(t, "Get", mock.Anything, d.Id)
+
+	// Roll20 game and date range
+	ret, err = recorder.ListRecordings(context.Background(), &pb.ListRecordingsRequest{Roll20GameId: "2"})
+	assert.NoError(t, err)
+	assert.Len(t, ret.Recordings, 1)
+	ret, err = recorder.ListRecordings(context.Background(), &pb.ListRecordingsRequest{From: t0.Add(-90 * time.Minute).UnixMilli(), To: t0.UnixMilli()})
+	assert.NoError(t, err)
+	assert.Len(t, ret.Recordings, 2)
+}
+
+func TestRecorder_ListRecordingsBoundedScan(t *testing.T) {
+	history := test_utils.MockHistoryStore{}
+	recorder := NewRecorder(&test_utils.MockDiscordRecorder{}, &test_utils.MockR20Recorder{}, &test_utils.MockStateStore{}, RecorderOpt{History: &history})
+	t0 := time.Now().Truncate(time.Millisecond)
+	// The only recording of the campaign is older than every other one
+	keys := make([]string, 0, MAX_SCANNED_RECORDS+1)
+	for i := 0; i <= MAX_SCANNED_RECORDS; i++ {
+		keys = append(keys, fmt.Sprintf("1-%d", t0.Add(-time.Duration(i)*time.Minute).UnixMilli()))
+	}
+	oldest := keys[MAX_SCANNED_RECORDS]
+	history.EXPECT().Keys(mock.Anything).Return(keys, nil)
+	history.EXPECT().Get(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, key string) (*memory.Record, error) {
+		record := &memory.Record{Id: key, VcId: "1", Metadata: memory.Metadata{Campaign: "Y"}}
+		if key == oldest {
+			record.Metadata.Campaign = "X"
+		}
+		return record, nil
+	})
+
+	ret, err := recorder.ListRecordings(context.Background(), &pb.ListRecordingsRequest{Campaign: "X"})
+	assert.NoError(t, err)
+	assert.Empty(t, ret.Recordings)
+	assert.NotEmpty(t, ret.NextPageToken)
+	history.AssertNumberOfCalls(t, "Get", MAX_SCANNED_RECORDS)
+
+	ret, err = recorder.ListRecordings(context.Background(), &pb.ListRecordingsRequest{Campaign: "X", PageToken: ret.NextPageToken})
+	assert.NoError(t, err)
+	assert.Len(t, ret.Recordings, 1)
+	assert.Equal(t, oldest, ret.Recordings[0].Id)
+	assert.Empty(t, ret.NextPageToken)
+}
+
+func TestRecorder_PruneHistory(t *testing.T) {
+	history := test_utils.MockHistoryStore{}
+	recorder := NewRecorder(&test_utils.MockDiscordRecorder{}, &test_utils.MockR20Recorder{}, &test_utils.MockStateStore{}, RecorderOpt{
+		History:          &history,
+		HistoryRetention: 24 * time.Hour,
+	})
+	old := fmt.Sprintf("1-%d", time.Now().Add(-48*time.Hour).UnixMilli())
+	recent := fmt.Sprintf("1-%d", time.Now().Add(-time.Hour).UnixMilli())
+	history.EXPECT().Keys(mock.Anything).Return([]string{old, recent, "unknown"}, nil)
+	history.EXPECT().Delete(mock.Anything, old).Return(nil)
+
+	assert.NoError(t, recorder.PruneHistory(context.Background()))
+	history.AssertNumberOfCalls(t, "Delete", 1)
+}
+
+func TestRecorder_ListRecordingsWrongToken(t *testing.T) {
+	history := test_utils.MockHistoryStore{}
+	recorder := NewRecorder(&test_utils.MockDiscordRecorder{}, &test_utils.MockR20Recorder{}, &test_utils.MockStateStore{}, RecorderOpt{History: &history})
+	_, err := recorder.ListRecordings(context.Background(), &pb.ListRecordingsRequest{PageToken: "wrong"})
+	assert.Error(t, err)
+}
+
+func TestRecorder_ListRecordingsByCampaign(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	history := test_utils.MockHistoryStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{History: &history})
+	history.EXPECT().Keys(mock.Anything).Return([]string{"a", "b"}, nil)
+	history.EXPECT().Get(mock.Anything, "a").Return(&memory.Record{Id: "a", Metadata: memory.Metadata{Campaign: "X", SessionNumber: 1}}, nil)
+	history.EXPECT().Get(mock.Anything, "b").Return(&memory.Record{Id: "b", Metadata: memory.Metadata{Campaign: "Y"}}, nil)
+
+	ret, err := recorder.ListRecordings(context.Background(), &pb.ListRecordingsRequest{Campaign: "X"})
+	assert.NoError(t, err)
+	assert.Len(t, ret.Recordings, 1)
+	assert.Equal(t, int32(1), ret.Recordings[0].Metadata.SessionNumber)
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"google.golang.org/protobuf/proto"
+	"log/slog"
+	"record-orchestrator/pkg/memory"
+	"time"
+)
+
+// Calls accepting an idempotency key
+const (
+	OpStart = "start"
+	OpStop  = "stop"
+)
+
+// Replies are kept this long, after which a retry is handled as a new call
+const REPLY_TTL = 24 * time.Hour
+
+// Idempotency keys are locked alongside the voice channels, which can't start with this prefix
+const IDEMPOTENCY_LOCK_PREFIX = "idempotency#"
+
+// Lock an idempotency key, so that a retry racing the original call waits for its reply
+// instead of handling the call again. Nothing is locked without a key
+func (r *Recorder) lockIdempotencyKey(idemKey string) func() {
+	if idemKey == "" || r.replies == nil {
+		return func() {}
+	}
+	return r.lock(IDEMPOTENCY_LOCK_PREFIX + idemKey)
+}
+
+// Fill reply with the stored reply of a previous call made with the same
+// idempotency key, returning whether there was one. Nothing is stored without a key
+func (r *Recorder) replay(ctx context.Context, idemKey, operation string, reply proto.Message) (bool, error) {
+	if idemKey == "" || r.replies == nil {
+		return false, nil
+	}
+	stored, err := r.replies.Get(ctx, idemKey)
+	if err != nil {
+		return false, err
+	}
+	if stored == nil || time.Since(stored.At) > REPLY_TTL {
+		return false, nil
+	}
+	if stored.Operation != operation {
+		return false, fmt.Errorf("[Recorder] :: idempotency key %s was already used for a %s", idemKey, stored.Operation)
+	}
+	if err = proto.Unmarshal(stored.Payload, reply); err != nil {
+		return false, err
+	}
+	slog.Info(fmt.Sprintf("[Recorder] :: Replaying %s reply for idempotency key %s", operation, idemKey))
+	return true, nil
+}
+
+// Keep the reply of a successful call made with an idempotency key.
+// The call already succeeded at this point, so a failure to keep it isn't reported to the caller.
+// The reply is kept even if the caller gave up, as it is the one that will retry
+func (r *Recorder) remember(ctx context.Context, idemKey, operation string, reply proto.Message) {
+	if idemKey == "" || r.replies == nil {
+		return
+	}
+	payload, err := proto.Marshal(reply)
+	if err == nil {
+		err = r.replies.Save(context.WithoutCancel(ctx), idemKey, memory.Reply{Operation: operation, Payload: payload, At: time.Now()})
+	}
+	if err != nil {
+		slog.Warn(fmt.Sprintf("[Recorder] :: Could not keep %s reply for idempotency key %s : %s", operation, idemKey, err.Error()))
+	}
+}
+
+// ForgetReplies removes the replies kept for longer than REPLY_TTL
+func (r *Recorder) ForgetReplies(ctx context.Context) error {
+	if r.replies == nil {
+		return nil
+	}
+	keys, err := r.replies.Keys(ctx)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, key := range keys {
+		stored, err := r.replies.Get(ctx, key)
+		if err == nil && (stored == nil || time.Since(stored.At) > REPLY_TTL) {
+			err = r.replies.Delete(ctx, key)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
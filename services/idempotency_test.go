@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/proto"
+	"record-orchestrator/pkg/memory"
+	pb "record-orchestrator/proto"
+	test_utils "record-orchestrator/test-utils"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Reply store backed by a map
+func replyStore(replies map[string]memory.Reply) *test_utils.MockReplyStore {
+	store := test_utils.MockReplyStore{}
+	store.EXPECT().Get(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, key string) (*memory.Reply, error) {
+		if r, ok := replies[key]; ok {
+			return &r, nil
+		}
+		return nil, nil
+	}).Maybe()
+	store.EXPECT().Save(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, key string, value memory.Reply) error {
+		replies[key] = value
+		return nil
+	}).Maybe()
+	store.EXPECT().Delete(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, key string) error {
+		delete(replies, key)
+		return nil
+	}).Maybe()
+	store.EXPECT().Keys(mock.Anything).RunAndReturn(func(context.Context) ([]string, error) {
+		keys := make([]string, 0, len(replies))
+		for k := range replies {
+			keys = append(keys, k)
+		}
+		return keys, nil
+	}).Maybe()
+	return &store
+}
+
+func TestRecorder_RetriedStart(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	replies := map[string]memory.Reply{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{Replies: replyStore(replies)})
+	var state *memory.State
+	mem.EXPECT().Get(mock.Anything, "1").RunAndReturn(func(context.Context, string) (*memory.State, error) {
+		return state, nil
+	})
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Run(func(ctx context.Context, key string, value memory.State) {
+		state = &value
+	}).Return(nil)
+	pandora.On("Start", mock.Anything, "1").Return("", nil).Once()
+	payload := &pb.StartRecordRequest{VoiceChannelId: "1", IdempotencyKey: "k"}
+
+	first, err := recorder.Start(context.Background(), payload)
+	assert.NoError(t, err)
+	assert.Contains(t, replies, "k")
+	// The session is already recording, but the retry gets the original reply
+	retried, err := recorder.Start(context.Background(), payload)
+	assert.NoError(t, err)
+	assert.Equal(t, first.SessionId, retried.SessionId)
+	assert.True(t, retried.Discord)
+	pandora.AssertNumberOfCalls(t, "Start", 1)
+
+	// Without a key, this is a new call
+	_, err = recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: "1"})
+	assert.Error(t, err)
+}
+
+func TestRecorder_RetriedStop(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	replies := map[string]memory.Reply{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{Replies: replyStore(replies)})
+	state := recordingState("1", "")
+	mem.EXPECT().Get(mock.Anything, "1").Return(state, nil).Once()
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Return(nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil)
+	pandora.On("Stop", mock.Anything, "", "1").Return([]string{"a", "b"}, nil).Once()
+	payload := &pb.StopRecordRequest{VoiceChannelId: "1", IdempotencyKey: "k"}
+
+	first, err := recorder.Stop(context.Background(), payload)
+	assert.NoError(t, err)
+	// The session is gone, but the retry still gets the keys
+	retried, err := recorder.Stop(context.Background(), payload)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, retried.DiscordKeys)
+	assert.True(t, proto.Equal(first, retried))
+	pandora.AssertNumberOfCalls(t, "Stop", 1)
+}
+
+// A retry arriving while the original call is still stopping gets its reply
+func TestRecorder_ConcurrentRetriedStop(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	replies := map[string]memory.Reply{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{Replies: replyStore(replies)})
+	var mu sync.Mutex
+	state := recordingState("1", "")
+	mem.EXPECT().Get(mock.Anything, "1").RunAndReturn(func(context.Context, string) (*memory.State, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return state, nil
+	})
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Return(nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Run(func(context.Context, string) {
+		mu.Lock()
+		defer mu.Unlock()
+		state = nil
+	}).Return(nil)
+	stopping, release := make(chan struct{}), make(chan struct{})
+	pandora.On("Stop", mock.Anything, "", "1").Run(func(mock.Arguments) {
+		close(stopping)
+		<-release
+	}).Return([]string{"a"}, nil).Once()
+	payload := &pb.StopRecordRequest{VoiceChannelId: "1", IdempotencyKey: "k"}
+
+	type result struct {
+		reply *pb.StopRecordReply
+		err   error
+	}
+	first, retried := make(chan result), make(chan result)
+	go func() {
+		reply, err := recorder.Stop(context.Background(), payload)
+		first <- result{reply, err}
+	}()
+	<-stopping
+	go func() {
+		reply, err := recorder.Stop(context.Background(), payload)
+		retried <- result{reply, err}
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+
+	f, r := <-first, <-retried
+	assert.NoError(t, f.err)
+	assert.NoError(t, r.err)
+	assert.True(t, proto.Equal(f.reply, r.reply))
+	pandora.AssertNumberOfCalls(t, "Stop", 1)
+}
+
+func TestRecorder_IdempotencyKeyReuse(t *testing.T) {
+	mem := test_utils.MockStateStore{}
+	replies := map[string]memory.Reply{
+		"k": {Operation: OpStart, At: time.Now()},
+	}
+	recorder := NewRecorder(nil, nil, &mem, RecorderOpt{Replies: replyStore(replies)})
+
+	// A key can't be used for another kind of call
+	_, err := recorder.Stop(context.Background(), &pb.StopRecordRequest{VoiceChannelId: "1", IdempotencyKey: "k"})
+	assert.ErrorContains(t, err, "already used")
+}
+
+func TestRecorder_ExpiredReply(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	mem := test_utils.MockStateStore{}
+	replies := map[string]memory.Reply{
+		"old":    {Operation: OpStart, At: time.Now().Add(-REPLY_TTL - time.Minute)},
+		"recent": {Operation: OpStart, At: time.Now()},
+	}
+	recorder := NewRecorder(&pandora, nil, &mem, RecorderOpt{Replies: replyStore(replies)})
+	mem.EXPECT().Get(mock.Anything, "1").Return(nil, nil)
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Return(nil)
+	pandora.On("Start", mock.Anything, "1").Return("", nil)
+
+	// An expired reply isn't replayed
+	reply, err := recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: "1", IdempotencyKey: "old"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, reply.SessionId)
+	pandora.AssertNumberOfCalls(t, "Start", 1)
+
+	replies["expired"] = memory.Reply{Operation: OpStop, At: time.Now().Add(-REPLY_TTL - time.Minute)}
+	assert.NoError(t, recorder.ForgetReplies(context.Background()))
+	assert.Contains(t, replies, "old")
+	assert.Contains(t, replies, "recent")
+	assert.NotContains(t, replies, "expired")
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"record-orchestrator/pkg/events"
+	"record-orchestrator/pkg/memory"
+	"record-orchestrator/pkg/pandora"
+)
+
+// Interrupt marks the session recording a voice channel as interrupted, as Pandora stopped recording it
+// on its own, typically kicked from the channel or restarted. The session goes on, Roll20 included,
+// until stopped, which then returns the tracks Pandora managed to upload instead of asking it again
+func (r *Recorder) Interrupt(ctx context.Context, i pandora.Interruption) error {
+	defer r.lock(i.VoiceChannelId)()
+	state, err := r.memory.Get(ctx, i.VoiceChannelId)
+	if err != nil {
+		return err
+	}
+	// Nothing to interrupt, or Pandora was already known to be done
+	if !isSession(state, "") || state.Discord.Status == memory.SourceStopped || state.Discord.Status == memory.SourceInterrupted {
+		slog.Info(fmt.Sprintf("[Recorder] :: Ignoring the interruption of voice channel %s, no session is recording it", i.VoiceChannelId))
+		return nil
+	}
+	state.Discord.SetStatus(memory.SourceInterrupted, errors.New(i.Reason))
+	state.DiscordKeys = i.Ids
+	if err = r.memory.Save(ctx, i.VoiceChannelId, *state); err != nil {
+		return err
+	}
+	slog.Warn(fmt.Sprintf("[Recorder] :: Session %s of voice channel %s interrupted : %s", state.Id, state.VcId, i.Reason))
+	interrupted := newEvent(events.Interrupted, state, i.Reason)
+	interrupted.DiscordKeys = i.Ids
+	r.emit(interrupted)
+	return nil
+}
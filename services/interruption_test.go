@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"record-orchestrator/pkg/events"
+	"record-orchestrator/pkg/memory"
+	"record-orchestrator/pkg/pandora"
+	pb "record-orchestrator/proto"
+	test_utils "record-orchestrator/test-utils"
+	"testing"
+)
+
+// Session whose Discord recording was interrupted, along with the tracks uploaded
+func interruptedState(vcId, r20Id string, keys ...string) *memory.State {
+	s := recordingState(vcId, r20Id)
+	s.Discord.SetStatus(memory.SourceInterrupted, assert.AnError)
+	s.DiscordKeys = keys
+	return s
+}
+
+// Pandora was kicked from the voice channel
+func TestRecorder_Interrupt(t *testing.T) {
+	mem := test_utils.MockStateStore{}
+	evts := test_utils.MockEmitter{}
+	recorder := NewRecorder(nil, nil, &mem, RecorderOpt{Events: &evts})
+	mem.EXPECT().Get(mock.Anything, "1").Return(recordingState("1", "2"), nil)
+	mem.EXPECT().Save(mock.Anything, "1", mock.MatchedBy(func(s memory.State) bool {
+		return s.Phase == memory.Recording && s.Discord.Status == memory.SourceInterrupted &&
+			s.Discord.Error == "kicked" && s.Roll20.Status == memory.SourceRecording && s.DiscordKeys[0] == "a"
+	})).Return(nil).Once()
+	evts.EXPECT().Emit(mock.MatchedBy(func(e events.Event) bool {
+		return e.Kind == events.Interrupted && e.Message == "kicked" && e.DiscordKeys[0] == "a"
+	})).Return(nil).Once()
+
+	err := recorder.Interrupt(context.Background(), pandora.Interruption{VoiceChannelId: "1", Reason: "kicked", Ids: []string{"a"}})
+	assert.NoError(t, err)
+	mem.AssertExpectations(t)
+	evts.AssertExpectations(t)
+}
+
+func TestRecorder_InterruptIgnored(t *testing.T) {
+	cases := map[string]*memory.State{
+		"no session":          nil,
+		"already interrupted": interruptedState("1", ""),
+	}
+	for name, state := range cases {
+		t.Run(name, func(t *testing.T) {
+			mem := test_utils.MockStateStore{}
+			evts := test_utils.MockEmitter{}
+			recorder := NewRecorder(nil, nil, &mem, RecorderOpt{Events: &evts})
+			mem.EXPECT().Get(mock.Anything, "1").Return(state, nil)
+
+			err := recorder.Interrupt(context.Background(), pandora.Interruption{VoiceChannelId: "1", Reason: "kicked"})
+			assert.NoError(t, err)
+			mem.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+			evts.AssertNotCalled(t, "Emit", mock.Anything)
+		})
+	}
+}
+
+// The tracks uploaded when interrupted are returned, Pandora isn't asked again
+func TestRecorder_StopInterruptedSession(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	evts := test_utils.MockEmitter{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{Events: &evts})
+	mem.EXPECT().Get(mock.Anything, "1").Return(interruptedState("1", "2", "a"), nil)
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Return(nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil)
+	r20Rec.On("Stop", mock.Anything, "2").Return("2.ogg", nil)
+	expectKind(&evts, events.PartiallyStopped)
+
+	reply, err := recorder.Stop(context.Background(), &pb.StopRecordRequest{VoiceChannelId: "1", Roll20GameId: "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a"}, reply.DiscordKeys)
+	assert.Equal(t, "2.ogg", reply.Roll20Key)
+	pandora.AssertNotCalled(t, "Stop", mock.Anything, mock.Anything, mock.Anything)
+	evts.AssertExpectations(t)
+}
+
+// Only Roll20 is paused once Pandora was interrupted
+func TestRecorder_PauseInterruptedSession(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	mem.EXPECT().Get(mock.Anything, "1").Return(interruptedState("1", "2"), nil)
+	mem.EXPECT().Save(mock.Anything, "1", mock.MatchedBy(func(s memory.State) bool {
+		return s.Phase == memory.Paused
+	})).Return(nil)
+	r20Rec.On("Pause", mock.Anything, "2").Return(nil)
+
+	reply, err := recorder.Pause(context.Background(), &pb.PauseRecordRequest{VoiceChannelId: "1"})
+	assert.NoError(t, err)
+	assert.False(t, reply.Discord)
+	assert.True(t, reply.Roll20)
+	pandora.AssertNotCalled(t, "Pause", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// An interrupted session isn't failed because Pandora isn't recording it anymore
+func TestRecorder_ReconcileInterruptedSession(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	mem := test_utils.MockStateStore{}
+	evts := test_utils.MockEmitter{}
+	recorder := NewRecorder(&pandora, nil, &mem, RecorderOpt{Events: &evts})
+	mem.EXPECT().Keys(mock.Anything).Return([]string{"1"}, nil)
+	mem.EXPECT().Get(mock.Anything, "1").Return(interruptedState("1", "", "a"), nil)
+
+	left, err := recorder.Reconcile(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, left)
+	// Tracked as it already was, nothing changed
+	mem.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+	mem.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	evts.AssertNotCalled(t, "Emit", mock.Anything)
+	pandora.AssertNotCalled(t, "IsRecording", mock.Anything, mock.Anything, mock.Anything)
+}
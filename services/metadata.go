@@ -0,0 +1,49 @@
+package services
+
+import (
+	"fmt"
+	"record-orchestrator/pkg/events"
+	"record-orchestrator/pkg/memory"
+	pb "record-orchestrator/proto"
+)
+
+func fromPbMetadata(metadata *pb.RecordingMetadata) (memory.Metadata, error) {
+	if metadata.GetSessionNumber() < 0 {
+		return memory.Metadata{}, fmt.Errorf("[Recorder] :: %w, session number cannot be negative but got %d", ErrInvalidArgument, metadata.GetSessionNumber())
+	}
+	return memory.Metadata{
+		Title:         metadata.GetTitle(),
+		Campaign:      metadata.GetCampaign(),
+		SessionNumber: metadata.GetSessionNumber(),
+		Labels:        metadata.GetLabels(),
+	}, nil
+}
+
+// The metadata of a session, nil if it had none
+func toPbMetadata(metadata memory.Metadata) *pb.RecordingMetadata {
+	if isEmptyMetadata(metadata) {
+		return nil
+	}
+	return &pb.RecordingMetadata{
+		Title:         metadata.Title,
+		Campaign:      metadata.Campaign,
+		SessionNumber: metadata.SessionNumber,
+		Labels:        metadata.Labels,
+	}
+}
+
+func toEventMetadata(metadata memory.Metadata) *events.Metadata {
+	if isEmptyMetadata(metadata) {
+		return nil
+	}
+	return &events.Metadata{
+		Title:         metadata.Title,
+		Campaign:      metadata.Campaign,
+		SessionNumber: metadata.SessionNumber,
+		Labels:        metadata.Labels,
+	}
+}
+
+func isEmptyMetadata(metadata memory.Metadata) bool {
+	return metadata.Title == "" && metadata.Campaign == "" && metadata.SessionNumber == 0 && len(metadata.Labels) == 0
+}
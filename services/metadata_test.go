@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"record-orchestrator/pkg/events"
+	"record-orchestrator/pkg/memory"
+	pb "record-orchestrator/proto"
+	test_utils "record-orchestrator/test-utils"
+	"testing"
+)
+
+func TestRecorder_Metadata(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	history := test_utils.MockHistoryStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{History: &history})
+	metadata := &pb.RecordingMetadata{
+		Title:         "The heist",
+		Campaign:      "Campaign X",
+		SessionNumber: 42,
+		Labels:        map[string]string{"system": "pf2e"},
+	}
+	var state *memory.State
+	mem.EXPECT().Get(mock.Anything, "1").RunAndReturn(func(context.Context, string) (*memory.State, error) {
+		return state, nil
+	})
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Run(func(ctx context.Context, key string, value memory.State) {
+		state = &value
+	}).Return(nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil)
+	pandora.On("Start", mock.Anything, "1").Return("", nil)
+	pandora.On("Stop", mock.Anything, "", "1").Return([]string{"a"}, nil)
+	history.EXPECT().Save(mock.Anything, mock.Anything, mock.MatchedBy(func(r memory.Record) bool {
+		return r.Metadata.Campaign == "Campaign X" && r.Metadata.SessionNumber == 42
+	})).Return(nil)
+	evts, cancel := recorder.Watch("1")
+	defer cancel()
+
+	_, err := recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: "1", Metadata: metadata})
+	assert.NoError(t, err)
+	status, err := recorder.GetRecording(context.Background(), &pb.GetRecordingRequest{VoiceChannelId: "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, metadata, status.Metadata)
+
+	ret, err := recorder.Stop(context.Background(), &pb.StopRecordRequest{VoiceChannelId: "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, metadata, ret.Metadata)
+	expected := &events.Metadata{
+		Title:         "The heist",
+		Campaign:      "Campaign X",
+		SessionNumber: 42,
+		Labels:        map[string]string{"system": "pf2e"},
+	}
+	for len(evts) > 0 {
+		e := <-evts
+		assert.Equal(t, expected, e.Metadata, e.Kind)
+	}
+	history.AssertExpectations(t)
+}
+
+func TestRecorder_NoMetadata(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	mem.EXPECT().Get(mock.Anything, "1").Return(recordingState("1", ""), nil)
+
+	status, err := recorder.GetRecording(context.Background(), &pb.GetRecordingRequest{VoiceChannelId: "1"})
+	assert.NoError(t, err)
+	assert.Nil(t, status.Metadata)
+	assert.Nil(t, newEvent(events.Warning, recordingState("1", ""), "").Metadata)
+}
+
+func TestRecorder_StartInvalidMetadata(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+
+	_, err := recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: "1", Metadata: &pb.RecordingMetadata{SessionNumber: -1}})
+	assert.Error(t, err)
+	pandora.AssertNotCalled(t, "Start", mock.Anything, mock.Anything)
+}
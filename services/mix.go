@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"record-orchestrator/pkg/events"
+	"record-orchestrator/pkg/memory"
+	"record-orchestrator/pkg/mixer"
+	pb "record-orchestrator/proto"
+	"time"
+)
+
+const (
+	DEFAULT_MIX_POLL_INTERVAL = 10 * time.Second
+	// Consecutive failures to get the status of a mix job before giving up on it
+	MAX_MIX_POLL_FAILURES = 5
+	// Mix jobs still unfinished this long after their session stopped are given up
+	DEFAULT_MIX_TIMEOUT = 2 * time.Hour
+	// Longest wait for the mixer to accept a job
+	MIX_SUBMIT_TIMEOUT = 30 * time.Second
+	// Longest wait for the history to save the progress of a mix job
+	MIX_SAVE_TIMEOUT = 10 * time.Second
+)
+
+// Mix job of a stopped session. It is submitted once the voice channel of
+// the session is unlocked, as the mixer may be slow to accept it
+type pendingMix struct {
+	recordId string
+	state    memory.State
+	job      mixer.Job
+}
+
+// The mix job of a stopped session, each track at its offset. Nil if mixing wasn't requested
+func newPendingMix(recordId string, state *memory.State, discordKeys []string, r20Key string, offsets map[string]int64) *pendingMix {
+	if !state.Mix.Requested {
+		return nil
+	}
+	p := &pendingMix{recordId: recordId, state: *state}
+	for _, key := range discordKeys {
+		p.job.Tracks = append(p.job.Tracks, mixer.Track{Key: key, Offset: offsets[key]})
+	}
+	if r20Key != "" {
+		p.job.Tracks = append(p.job.Tracks, mixer.Track{Key: r20Key, Offset: offsets[r20Key]})
+	}
+	return p
+}
+
+// Submit a pending mix job, and follow it in the background. The recording itself is over,
+// so failing to submit the job doesn't fail the stop. The reply, if any, describes the job
+func (r *Recorder) submitMix(ctx context.Context, p *pendingMix, reply *pb.StopRecordReply) {
+	if p == nil {
+		return
+	}
+	state := &p.state
+	var err error
+	switch {
+	case r.mixer == nil:
+		err = fmt.Errorf("mixing is disabled")
+	case len(p.job.Tracks) == 0:
+		err = fmt.Errorf("no track to mix")
+	default:
+		// The session is already stopped, so the job is submitted even if the caller gave up
+		submitCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), MIX_SUBMIT_TIMEOUT)
+		state.Mix.Id, err = r.mixer.Mix(submitCtx, p.job)
+		cancel()
+	}
+	if err != nil {
+		state.Mix.Status, state.Mix.Error = string(mixer.Failed), err.Error()
+		slog.Warn(fmt.Sprintf("[Recorder] :: Could not mix the tracks of session %s : %s", state.VcId, err.Error()))
+		r.emit(newEvent(events.MixFailed, state, fmt.Sprintf("could not submit the mix job : %s", err.Error())))
+	} else {
+		state.Mix.Status = string(mixer.Pending)
+	}
+	r.saveMix(ctx, p.recordId, state.Mix)
+	if reply != nil {
+		reply.Mix = toPbMix(state.Mix)
+	}
+	if err == nil {
+		r.followMix(ctx, p.recordId, *state)
+	}
+}
+
+// Follow a submitted mix job in the background, until it ends or the mix timeout elapsed since its session stopped.
+// The job outlives the call that submitted it, so only the values of ctx are kept
+func (r *Recorder) followMix(ctx context.Context, recordId string, state memory.State) {
+	stoppedAt, _ := state.EnteredAt(state.Phase)
+	ctx, cancel := context.WithDeadline(context.WithoutCancel(ctx), stoppedAt.Add(r.mixTimeout))
+	go func() {
+		defer cancel()
+		r.trackMix(ctx, recordId, state)
+	}()
+}
+
+// Follow a submitted mix job until it ends or ctx is done, keeping its progress in the history record of the session
+func (r *Recorder) trackMix(ctx context.Context, recordId string, state memory.State) {
+	failures := 0
+	for {
+		var status *mixer.JobStatus
+		select {
+		case <-ctx.Done():
+			status = &mixer.JobStatus{Status: mixer.Failed, Error: fmt.Sprintf("gave up on the job, still %s after %s", state.Mix.Status, r.mixTimeout)}
+		case <-time.After(r.mixPollInterval):
+			var err error
+			status, err = r.mixer.Status(ctx, state.Mix.Id)
+			// Giving up is handled on the next iteration
+			if err != nil && ctx.Err() != nil {
+				continue
+			}
+			if err != nil {
+				failures++
+				slog.Warn(fmt.Sprintf("[Recorder] :: Could not get the status of mix job %s : %s", state.Mix.Id, err.Error()))
+				if failures < MAX_MIX_POLL_FAILURES {
+					continue
+				}
+				status = &mixer.JobStatus{Status: mixer.Failed, Error: fmt.Sprintf("lost track of the job : %s", err.Error())}
+			}
+		}
+		failures = 0
+		if string(status.Status) != state.Mix.Status || status.Progress != state.Mix.Progress {
+			state.Mix.Status, state.Mix.Progress = string(status.Status), status.Progress
+			state.Mix.Key, state.Mix.Error = status.Key, status.Error
+			r.saveMix(ctx, recordId, state.Mix)
+		}
+
+		switch status.Status {
+		case mixer.Done:
+			mixed := newEvent(events.Mixed, &state, "")
+			mixed.MixKey = status.Key
+			r.emit(mixed)
+			return
+		case mixer.Failed:
+			slog.Warn(fmt.Sprintf("[Recorder] :: Mix job %s failed : %s", state.Mix.Id, status.Error))
+			r.emit(newEvent(events.MixFailed, &state, status.Error))
+			return
+		}
+	}
+}
+
+// ResumeMixes follows the mix jobs left unfinished by a restart of the orchestrator again,
+// submitting the ones that weren't submitted yet
+func (r *Recorder) ResumeMixes(ctx context.Context) error {
+	if r.history == nil || r.mixer == nil {
+		return nil
+	}
+	keys, err := r.history.Keys(ctx)
+	if err != nil {
+		return err
+	}
+	resumed := 0
+	for _, key := range keys {
+		record, err := r.history.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+		// Aborted sessions are never mixed
+		if record == nil || record.Aborted || !record.Mix.Requested {
+			continue
+		}
+		state := fromRecord(record)
+		switch mixer.Status(record.Mix.Status) {
+		case "":
+			if time.Since(record.StoppedAt) < r.mixTimeout {
+				go r.submitMix(ctx, newPendingMix(record.Id, state, record.DiscordKeys, record.R20Key, record.Offsets), nil)
+			} else {
+				r.followMix(ctx, record.Id, *state)
+			}
+		case mixer.Pending, mixer.Running:
+			r.followMix(ctx, record.Id, *state)
+		default:
+			continue
+		}
+		resumed++
+	}
+	slog.Info(fmt.Sprintf("[Recorder] :: Resumed %d unfinished mix jobs", resumed))
+	return nil
+}
+
+// Best effort update of the mix job of a history record.
+// The outcome of a given up job must still be saved, so the update gets its own deadline
+func (r *Recorder) saveMix(ctx context.Context, recordId string, job memory.MixJob) {
+	if r.history == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), MIX_SAVE_TIMEOUT)
+	defer cancel()
+	record, err := r.history.Get(ctx, recordId)
+	if err == nil && record != nil {
+		record.Mix = job
+		err = r.history.Save(ctx, recordId, *record)
+	}
+	if err != nil {
+		slog.Warn(fmt.Sprintf("[Recorder] :: Could not save the progress of mix job %s : %s", job.Id, err.Error()))
+	}
+}
+
+// The session a history record was made of, as much as the record tells
+func fromRecord(record *memory.Record) *memory.State {
+	phase := memory.Stopped
+	if record.Aborted {
+		phase = memory.Failed
+	}
+	return &memory.State{
+		Id:          record.SessionId,
+		VcId:        record.VcId,
+		R20Id:       record.R20Id,
+		Phase:       phase,
+		Transitions: []memory.Transition{{Phase: phase, At: record.StoppedAt}},
+		Metadata:    record.Metadata,
+		Mix:         record.Mix,
+	}
+}
+
+// The mix job of a session, nil if it wasn't requested
+func toPbMix(job memory.MixJob) *pb.MixJob {
+	if !job.Requested {
+		return nil
+	}
+	return &pb.MixJob{
+		Id:       job.Id,
+		Status:   job.Status,
+		Progress: job.Progress,
+		Key:      job.Key,
+		Error:    job.Error,
+	}
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"record-orchestrator/pkg/events"
+	"record-orchestrator/pkg/memory"
+	"record-orchestrator/pkg/mixer"
+	pb "record-orchestrator/proto"
+	test_utils "record-orchestrator/test-utils"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRecorder_StopSubmitsMix(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	history := test_utils.MockHistoryStore{}
+	mix := test_utils.MockAudioMixer{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{
+		History:         &history,
+		Mixer:           &mix,
+		MixPollInterval: time.Millisecond,
+	})
+	state := recordingState("1", "2")
+	state.Offsets = map[string]int64{SourceDiscord: 0, SourceRoll20: 300}
+	state.Mix.Requested = true
+	var record memory.Record
+	mem.EXPECT().Get(mock.Anything, "1").Return(state, nil)
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Return(nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil)
+	pandora.On("Stop", mock.Anything, "", "1").Return([]string{"a", "b"}, nil)
+	r20Rec.On("Stop", mock.Anything, "2").Return("2.ogg", nil)
+	history.EXPECT().Save(mock.Anything, mock.Anything, mock.Anything).Run(func(ctx context.Context, key string, value memory.Record) {
+		record = value
+	}).Return(nil)
+	history.EXPECT().Get(mock.Anything, mock.Anything).RunAndReturn(func(context.Context, string) (*memory.Record, error) {
+		r := record
+		return &r, nil
+	})
+	mix.EXPECT().Mix(mock.Anything, mixer.Job{Tracks: []mixer.Track{{Key: "a"}, {Key: "b"}, {Key: "2.ogg", Offset: 300}}}).Run(func(context.Context, mixer.Job) {
+		// The voice channel must not be locked while the mixer takes its time
+		defer recorder.lock("1")()
+	}).Return("job", nil)
+	mix.EXPECT().Status(mock.Anything, "job").Return(&mixer.JobStatus{Status: mixer.Running, Progress: 0.5}, nil).Once()
+	mix.EXPECT().Status(mock.Anything, "job").Return(&mixer.JobStatus{Status: mixer.Done, Progress: 1, Key: "mixed.ogg"}, nil).Once()
+	evts, cancel := recorder.Watch("1")
+	defer cancel()
+
+	ret, err := recorder.Stop(context.Background(), &pb.StopRecordRequest{VoiceChannelId: "1", Roll20GameId: "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "job", ret.Mix.Id)
+	assert.Equal(t, string(mixer.Pending), ret.Mix.Status)
+
+	// The progress of the job ends up in the history
+	var mixed events.Event
+	assert.Eventually(t, func() bool {
+		select {
+		case mixed = <-evts:
+			return mixed.Kind == events.Mixed
+		default:
+			return false
+		}
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, "mixed.ogg", mixed.MixKey)
+	assert.Equal(t, memory.MixJob{Requested: true, Id: "job", Status: string(mixer.Done), Progress: 1, Key: "mixed.ogg"}, record.Mix)
+	mix.AssertExpectations(t)
+}
+
+func TestRecorder_StopWithoutMix(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	mix := test_utils.MockAudioMixer{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{Mixer: &mix})
+	mem.EXPECT().Get(mock.Anything, "1").Return(recordingState("1", ""), nil)
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Return(nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil)
+	pandora.On("Stop", mock.Anything, "", "1").Return([]string{"a"}, nil)
+
+	ret, err := recorder.Stop(context.Background(), &pb.StopRecordRequest{VoiceChannelId: "1"})
+	assert.NoError(t, err)
+	assert.Nil(t, ret.Mix)
+	mix.AssertNotCalled(t, "Mix", mock.Anything, mock.Anything)
+}
+
+func TestRecorder_StopMixSubmitFailure(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	mix := test_utils.MockAudioMixer{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{Mixer: &mix})
+	mem.EXPECT().Get(mock.Anything, "1").Return(recordingState("1", ""), nil)
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Return(nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil)
+	pandora.On("Stop", mock.Anything, "", "1").Return([]string{"a"}, nil)
+	mix.EXPECT().Mix(mock.Anything, mock.Anything).Return("", fmt.Errorf("mixer down"))
+
+	// Asking for the mix on stop is enough
+	ret, err := recorder.Stop(context.Background(), &pb.StopRecordRequest{VoiceChannelId: "1", Mix: true})
+	assert.NoError(t, err)
+	assert.Equal(t, string(mixer.Failed), ret.Mix.Status)
+	assert.Equal(t, "mixer down", ret.Mix.Error)
+	mix.AssertNotCalled(t, "Status", mock.Anything, mock.Anything)
+}
+
+func TestRecorder_TrackMixLosesJob(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	mix := test_utils.MockAudioMixer{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{Mixer: &mix, MixPollInterval: time.Millisecond})
+	state := recordingState("1", "")
+	state.Mix = memory.MixJob{Requested: true, Id: "job", Status: string(mixer.Pending)}
+	mix.EXPECT().Status(mock.Anything, "job").Return(nil, fmt.Errorf("mixer down")).Times(MAX_MIX_POLL_FAILURES)
+	evts, cancel := recorder.Watch("1")
+	defer cancel()
+
+	recorder.trackMix(context.Background(), "1-0", *state)
+	assert.Equal(t, []events.Kind{events.MixFailed}, drain(evts))
+	mix.AssertExpectations(t)
+}
+
+// A job that never ends is given up
+func TestRecorder_TrackMixTimeout(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	mix := test_utils.MockAudioMixer{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{Mixer: &mix, MixPollInterval: time.Millisecond, MixTimeout: 50 * time.Millisecond})
+	state := recordingState("1", "")
+	_ = state.Transition(memory.Stopping)
+	_ = state.Transition(memory.Stopped)
+	state.Mix = memory.MixJob{Requested: true, Id: "job", Status: string(mixer.Running)}
+	mix.EXPECT().Status(mock.Anything, "job").Return(&mixer.JobStatus{Status: mixer.Running}, nil)
+	evts, cancel := recorder.Watch("1")
+	defer cancel()
+
+	recorder.followMix(context.Background(), "1-0", *state)
+	var failed events.Event
+	assert.Eventually(t, func() bool {
+		select {
+		case failed = <-evts:
+			return failed.Kind == events.MixFailed
+		default:
+			return false
+		}
+	}, time.Second, time.Millisecond)
+	assert.Contains(t, failed.Message, "gave up")
+}
+
+// The progress of a job is saved within a deadline, even once the caller gave up
+func TestRecorder_SaveMixBounded(t *testing.T) {
+	mem := test_utils.MockStateStore{}
+	history := test_utils.MockHistoryStore{}
+	recorder := NewRecorder(nil, nil, &mem, RecorderOpt{History: &history})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	bounded := mock.MatchedBy(func(ctx context.Context) bool {
+		_, ok := ctx.Deadline()
+		return ok && ctx.Err() == nil
+	})
+	history.EXPECT().Get(bounded, "1-0").Return(&memory.Record{Id: "1-0"}, nil)
+	history.EXPECT().Save(bounded, "1-0", memory.Record{Id: "1-0", Mix: memory.MixJob{Requested: true, Status: string(mixer.Failed)}}).Return(nil)
+
+	recorder.saveMix(ctx, "1-0", memory.MixJob{Requested: true, Status: string(mixer.Failed)})
+	history.AssertExpectations(t)
+}
+
+// Jobs left unfinished by a restart are followed again, and the ones never submitted are submitted
+func TestRecorder_ResumeMixes(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	history := test_utils.MockHistoryStore{}
+	mix := test_utils.MockAudioMixer{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{History: &history, Mixer: &mix, MixPollInterval: time.Millisecond})
+	var mu sync.Mutex
+	records := map[string]memory.Record{
+		"running":   {Id: "running", VcId: "1", StoppedAt: time.Now(), Mix: memory.MixJob{Requested: true, Id: "job1", Status: string(mixer.Running)}},
+		"submitted": {Id: "submitted", VcId: "2", StoppedAt: time.Now(), DiscordKeys: []string{"a"}, Mix: memory.MixJob{Requested: true}},
+		"done":      {Id: "done", VcId: "3", StoppedAt: time.Now(), Mix: memory.MixJob{Requested: true, Id: "job3", Status: string(mixer.Done)}},
+		"aborted":   {Id: "aborted", VcId: "4", StoppedAt: time.Now(), Aborted: true, Mix: memory.MixJob{Requested: true}},
+	}
+	history.EXPECT().Keys(mock.Anything).Return([]string{"running", "submitted", "done", "aborted"}, nil)
+	history.EXPECT().Get(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, key string) (*memory.Record, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		r := records[key]
+		return &r, nil
+	})
+	history.EXPECT().Save(mock.Anything, mock.Anything, mock.Anything).Run(func(ctx context.Context, key string, value memory.Record) {
+		mu.Lock()
+		defer mu.Unlock()
+		records[key] = value
+	}).Return(nil)
+	mix.EXPECT().Mix(mock.Anything, mixer.Job{Tracks: []mixer.Track{{Key: "a"}}}).Return("job2", nil).Once()
+	mix.EXPECT().Status(mock.Anything, mock.Anything).Return(&mixer.JobStatus{Status: mixer.Done, Progress: 1, Key: "mixed.ogg"}, nil)
+
+	assert.NoError(t, recorder.ResumeMixes(context.Background()))
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return records["running"].Mix.Status == string(mixer.Done) && records["submitted"].Mix.Status == string(mixer.Done)
+	}, time.Second, time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, "job2", records["submitted"].Mix.Id)
+	assert.Equal(t, memory.MixJob{Requested: true}, records["aborted"].Mix)
+	mix.AssertNotCalled(t, "Status", mock.Anything, "job3")
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"record-orchestrator/pkg/memory"
+	"time"
+)
+
+// Recording sources, as referenced in the offsets of a session
+const (
+	SourceDiscord = "discord"
+	SourceRoll20  = "roll20"
+)
+
+// Compute the offset of each recording source of a session, from the
+// earliest one to start recording, which is also returned. Only the sources
+// that acknowledged the start are taken into account
+func computeOffsets(state *memory.State) (map[string]int64, time.Time) {
+	starts := make(map[string]time.Time)
+	if state.Discord.Status == memory.SourceRecording {
+		starts[SourceDiscord] = state.Discord.StartedAt()
+	}
+	if state.Roll20.Status == memory.SourceRecording {
+		starts[SourceRoll20] = state.Roll20.StartedAt()
+	}
+
+	var earliest time.Time
+	for _, start := range starts {
+		if earliest.IsZero() || start.Before(earliest) {
+			earliest = start
+		}
+	}
+	offsets := make(map[string]int64, len(starts))
+	for source, start := range starts {
+		offsets[source] = start.Sub(earliest).Milliseconds()
+	}
+	return offsets, earliest
+}
+
+// Convert the pauses of a session to offsets from its origin.
+// A pause still ongoing ends when the session stopped
+func pauseOffsets(state *memory.State, stoppedAt time.Time) []memory.PauseOffset {
+	pauses := make([]memory.PauseOffset, 0, len(state.Pauses))
+	for _, p := range state.Pauses {
+		end := p.End
+		if end.IsZero() {
+			end = stoppedAt
+		}
+		pauses = append(pauses, memory.PauseOffset{
+			Start: p.Start.Sub(state.Origin).Milliseconds(),
+			End:   end.Sub(state.Origin).Milliseconds(),
+		})
+	}
+	return pauses
+}
+
+// Spread the offsets of the sources onto the tracks they produced.
+// Every Discord track starts along with the Discord recording
+func trackOffsets(offsets map[string]int64, discordKeys []string, r20Key string) map[string]int64 {
+	tracks := make(map[string]int64)
+	if offset, ok := offsets[SourceDiscord]; ok {
+		for _, key := range discordKeys {
+			tracks[key] = offset
+		}
+	}
+	if offset, ok := offsets[SourceRoll20]; ok && r20Key != "" {
+		tracks[r20Key] = offset
+	}
+	return tracks
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"github.com/stretchr/testify/assert"
+	"record-orchestrator/pkg/memory"
+	"testing"
+	"time"
+)
+
+func TestOffsets_RoundTripCompensation(t *testing.T) {
+	t0 := time.Now()
+	state := memory.NewState("1")
+	// Pandora took 1s to acknowledge, so it started 500ms in
+	state.Discord = memory.Source{Status: memory.SourceRecording, RequestedAt: t0, AckAt: t0.Add(time.Second)}
+	// Roll20 took 200ms to acknowledge, starting 1.1s in
+	state.Roll20 = memory.Source{Status: memory.SourceRecording, RequestedAt: t0.Add(time.Second), AckAt: t0.Add(1200 * time.Millisecond)}
+
+	offsets, origin := computeOffsets(state)
+	assert.Equal(t, map[string]int64{SourceDiscord: 0, SourceRoll20: 600}, offsets)
+	assert.Equal(t, t0.Add(500*time.Millisecond), origin)
+
+	tracks := trackOffsets(offsets, []string{"a", "b"}, "2.ogg")
+	assert.Equal(t, map[string]int64{"a": 0, "b": 0, "2.ogg": 600}, tracks)
+}
+
+func TestOffsets_FailedSourceIgnored(t *testing.T) {
+	t0 := time.Now()
+	state := memory.NewState("1")
+	state.Discord = memory.Source{Status: memory.SourceRecording, RequestedAt: t0, AckAt: t0.Add(time.Second)}
+	state.Roll20 = memory.Source{Status: memory.SourceFailed, RequestedAt: t0.Add(time.Second), AckAt: t0.Add(2 * time.Second)}
+
+	offsets, _ := computeOffsets(state)
+	assert.Equal(t, map[string]int64{SourceDiscord: 0}, offsets)
+	assert.Equal(t, map[string]int64{"a": 0}, trackOffsets(offsets, []string{"a"}, ""))
+}
+
+func TestOffsets_Pauses(t *testing.T) {
+	t0 := time.Now()
+	state := memory.NewState("1")
+	state.Origin = t0
+	state.Pauses = []memory.Pause{
+		{Start: t0.Add(time.Minute), End: t0.Add(2 * time.Minute)},
+		// Stopped while paused
+		{Start: t0.Add(3 * time.Minute)},
+	}
+	pauses := pauseOffsets(state, t0.Add(4*time.Minute))
+	assert.Equal(t, []memory.PauseOffset{{Start: 60000, End: 120000}, {Start: 180000, End: 240000}}, pauses)
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"record-orchestrator/pkg/events"
+	"record-orchestrator/pkg/memory"
+	pb "record-orchestrator/proto"
+	"time"
+)
+
+// Pause every source of the session recording a voice channel.
+// Sources are paused together or not at all, so that they stay in sync
+func (r *Recorder) Pause(ctx context.Context, payload *pb.PauseRecordRequest) (*pb.PauseRecordReply, error) {
+	discord, roll20, err := r.togglePause(ctx, payload.GetSessionId(), payload.VoiceChannelId, true)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.PauseRecordReply{Discord: discord, Roll20: roll20}, nil
+}
+
+// Resume every source of a paused session
+func (r *Recorder) Resume(ctx context.Context, payload *pb.ResumeRecordRequest) (*pb.ResumeRecordReply, error) {
+	discord, roll20, err := r.togglePause(ctx, payload.GetSessionId(), payload.VoiceChannelId, false)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ResumeRecordReply{Discord: discord, Roll20: roll20}, nil
+}
+
+// Pause or resume the sources of the session sessionId, or recording vcId,
+// returning which of Discord and Roll20 were affected. An interrupted Discord recording is left alone
+func (r *Recorder) togglePause(ctx context.Context, sessionId, vcId string, pause bool) (bool, bool, error) {
+	key, err := r.locate(ctx, sessionId, vcId)
+	if err != nil {
+		return false, false, err
+	}
+	defer r.lock(key)()
+
+	state, err := r.memory.Get(ctx, key)
+	if err != nil {
+		return false, false, err
+	}
+	if !isSession(state, sessionId) {
+		return false, false, fmt.Errorf("%w recording voice channel %s", ErrNoSession, key)
+	}
+	name, target, kind := "resume", memory.Recording, events.Resumed
+	discordDo, discordUndo := r.pandora.Resume, r.pandora.Pause
+	r20Do, r20Undo := r.roll20Sync.Resume, r.roll20Sync.Pause
+	if pause {
+		name, target, kind = "pause", memory.Paused, events.Paused
+		discordDo, discordUndo = r.pandora.Pause, r.pandora.Resume
+		r20Do, r20Undo = r.roll20Sync.Pause, r.roll20Sync.Resume
+	}
+	if !state.CanTransition(target) {
+		return false, false, &memory.ErrIllegalTransition{From: state.Phase, To: target}
+	}
+
+	// If a source cannot follow, the others are brought back to
+	// where they were, as they would otherwise be out of sync.
+	// This holds even if the caller gave up halfway
+	sg := newSaga(name)
+	detached := context.WithoutCancel(ctx)
+	discord := state.Discord.Status != memory.SourceInterrupted
+	if discord {
+		if err = discordDo(ctx, state.PandoraInstance, state.VcId); err != nil {
+			return false, false, sg.abort("pandora", err)
+		}
+		sg.onRollback("pandora", func() error {
+			return discordUndo(detached, state.PandoraInstance, state.VcId)
+		})
+	}
+	roll20 := state.R20Id != "" && state.Roll20.Status == memory.SourceRecording
+	if roll20 {
+		if err = r20Do(ctx, state.R20Id); err != nil {
+			return false, false, sg.abort("roll20", err)
+		}
+		sg.onRollback("roll20", func() error {
+			return r20Undo(detached, state.R20Id)
+		})
+	}
+
+	now := time.Now()
+	if pause {
+		state.Pause(now)
+	} else {
+		state.Resume(now)
+	}
+	err = state.Transition(target)
+	if err == nil {
+		err = r.memory.Save(ctx, key, *state)
+	}
+	if err != nil {
+		return false, false, sg.abort("memory", err)
+	}
+	r.emit(newEvent(kind, state, ""))
+	return discord, roll20, nil
+}
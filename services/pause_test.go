@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"record-orchestrator/pkg/memory"
+	pb "record-orchestrator/proto"
+	test_utils "record-orchestrator/test-utils"
+	"testing"
+)
+
+func TestRecorder_PauseAndResume(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	state := recordingState("1", "2")
+	var last memory.State
+	mem.EXPECT().Get(mock.Anything, "1").RunAndReturn(func(context.Context, string) (*memory.State, error) {
+		return state, nil
+	})
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Run(func(ctx context.Context, key string, value memory.State) {
+		last = value
+	}).Return(nil)
+	pandora.On("Pause", mock.Anything, "", "1").Return(nil)
+	pandora.On("Resume", mock.Anything, "", "1").Return(nil)
+	r20Rec.On("Pause", mock.Anything, "2").Return(nil)
+	r20Rec.On("Resume", mock.Anything, "2").Return(nil)
+
+	ret, err := recorder.Pause(context.Background(), &pb.PauseRecordRequest{VoiceChannelId: "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.PauseRecordReply{Discord: true, Roll20: true}, ret)
+	assert.Equal(t, memory.Paused, last.Phase)
+	assert.Len(t, last.Pauses, 1)
+	assert.True(t, last.Pauses[0].End.IsZero())
+
+	// Already paused
+	_, err = recorder.Pause(context.Background(), &pb.PauseRecordRequest{VoiceChannelId: "1"})
+	var tErr *memory.ErrIllegalTransition
+	assert.ErrorAs(t, err, &tErr)
+
+	_, err = recorder.Resume(context.Background(), &pb.ResumeRecordRequest{VoiceChannelId: "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, memory.Recording, last.Phase)
+	assert.False(t, last.Pauses[0].End.IsZero())
+	pandora.AssertExpectations(t)
+	r20Rec.AssertExpectations(t)
+}
+
+func TestRecorder_PauseRoll20FailureKeepsSync(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	mem.EXPECT().Get(mock.Anything, "1").Return(recordingState("1", "2"), nil)
+	pandora.On("Pause", mock.Anything, "", "1").Return(nil)
+	// Pandora must be resumed, as Roll20 keeps recording
+	pandora.On("Resume", mock.Anything, "", "1").Return(nil)
+	r20Rec.On("Pause", mock.Anything, "2").Return(fmt.Errorf("roll20 down"))
+
+	_, err := recorder.Pause(context.Background(), &pb.PauseRecordRequest{VoiceChannelId: "1"})
+	var sErr *SagaError
+	assert.ErrorAs(t, err, &sErr)
+	assert.Equal(t, []string{"pandora"}, sErr.Compensations)
+	pandora.AssertExpectations(t)
+	mem.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRecorder_PauseNotRecording(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	mem.EXPECT().Get(mock.Anything, "1").Return(nil, nil)
+	_, err := recorder.Pause(context.Background(), &pb.PauseRecordRequest{VoiceChannelId: "1"})
+	assert.ErrorIs(t, err, ErrNoSession)
+	pandora.AssertNotCalled(t, "Pause", mock.Anything, mock.Anything, mock.Anything)
+}
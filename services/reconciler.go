@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"record-orchestrator/pkg/events"
+	"record-orchestrator/pkg/memory"
+	"time"
+)
+
+// Decision taken about a persisted session during a reconciliation
+type Decision string
+
+const (
+	// The sources are still recording, the session is tracked again
+	Resumed Decision = "resumed"
+	// The session was stopping, the stop was completed
+	Finished Decision = "finished"
+	// The sources aren't recording anymore, the session is marked as failed
+	MarkedFailed Decision = "failed"
+	// The session had already ended, its leftover state was removed
+	Cleared Decision = "cleared"
+)
+
+// Reconcile compares every persisted session with the actual state of its sources.
+// This is meant to be run when the orchestrator boots, as it may have been restarted
+// in the middle of a session, and optionally at regular intervals afterwards.
+// Sessions that could not be reconciled are left as is, and their keys returned to be retried
+func (r *Recorder) Reconcile(ctx context.Context) ([]string, error) {
+	if err := r.adoptLegacy(ctx); err != nil {
+		return nil, fmt.Errorf("[Reconciler] :: could not adopt the session of a previous version : %w", err)
+	}
+	keys, err := r.memory.Keys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.ReconcileSessions(ctx, keys)
+}
+
+// ReconcileSessions reconciles the sessions stored under keys only, returning the keys of the ones left as is
+func (r *Recorder) ReconcileSessions(ctx context.Context, keys []string) ([]string, error) {
+	var errs []error
+	var left []string
+	for _, key := range keys {
+		if err := r.reconcile(ctx, key); err != nil {
+			slog.Error(fmt.Sprintf("[Reconciler] :: Could not reconcile session %s : %s", key, err.Error()))
+			errs = append(errs, err)
+			left = append(left, key)
+		}
+	}
+	return left, errors.Join(errs...)
+}
+
+// Reconcile a single session, decide what to do with it and publish the decision.
+// A session that is tracked as it already was isn't worth a decision
+func (r *Recorder) reconcile(ctx context.Context, key string) error {
+	defer r.lock(key)()
+	state, err := r.memory.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	// Already deleted, the index wasn't updated yet
+	if state == nil {
+		return nil
+	}
+	from := state.Phase
+	evt := newEvent(events.Reconciled, state, "")
+	changed := true
+
+	switch state.Phase {
+	case memory.Stopped, memory.Failed:
+		err = r.memory.Delete(ctx, key)
+		evt.Decision, evt.Message = string(Cleared), "session had already ended"
+
+	case memory.Stopping:
+		reply, mix, sErr := r.stop(ctx, key, state)
+		if sErr != nil {
+			return sErr
+		}
+		// Submitted once the voice channel is unlocked
+		go r.submitMix(ctx, mix, nil)
+		evt.DiscordKeys, evt.Roll20Key = reply.DiscordKeys, reply.Roll20Key
+		evt.Decision, evt.Message = string(Finished), "completed a stop interrupted halfway"
+
+	case memory.Compensating:
+		evt.Decision, evt.Message = string(MarkedFailed), "completed a compensation interrupted halfway"
+		err = r.fail(ctx, key, state, evt.Message)
+
+	default:
+		// Pandora already stopped on its own, there is nothing to probe
+		if state.Discord.Status == memory.SourceInterrupted {
+			changed, err = r.resume(ctx, key, state)
+			evt.Decision, evt.Message = string(Resumed), "Pandora was interrupted, the session goes on until stopped"
+			break
+		}
+		recording, pErr := r.pandora.IsRecording(ctx, state.PandoraInstance, state.VcId)
+		// Pandora may well still be recording, the session is left as is until the next reconciliation
+		if pErr != nil {
+			return fmt.Errorf("[Reconciler] :: could not probe Pandora, leaving session %s as is : %w", key, pErr)
+		}
+		if !recording {
+			state.Discord.SetStatus(memory.SourceFailed, nil)
+			evt.Decision, evt.Message = string(MarkedFailed), "Pandora isn't recording the voice channel anymore"
+			err = r.fail(ctx, key, state, evt.Message)
+			break
+		}
+		changed, err = r.resume(ctx, key, state)
+		evt.Decision, evt.Message = string(Resumed), "Pandora is still recording the voice channel"
+	}
+	if err != nil || !changed {
+		return err
+	}
+
+	evt.Phase = string(state.Phase)
+	evt.At = time.Now()
+	slog.Info(fmt.Sprintf("[Reconciler] :: Session %s (%s) : %s, %s", key, from, evt.Decision, evt.Message))
+	r.emit(evt)
+	return nil
+}
+
+// Versions predating the sessions namespace stored their single session under a key of its own,
+// as long as it was recording. It is moved to the namespace, to be reconciled like any other session
+func (r *Recorder) adoptLegacy(ctx context.Context) error {
+	if r.legacy == nil {
+		return nil
+	}
+	legacy, err := r.legacy.Get(ctx)
+	if err != nil || legacy == nil {
+		return err
+	}
+	if legacy.VcId != "" {
+		if err = r.adopt(ctx, legacy); err != nil {
+			return err
+		}
+	}
+	return r.legacy.Delete(ctx)
+}
+
+func (r *Recorder) adopt(ctx context.Context, legacy *memory.LegacySession) error {
+	defer r.lock(legacy.VcId)()
+	state, err := r.memory.Get(ctx, legacy.VcId)
+	if err != nil {
+		return err
+	}
+	// The voice channel was recorded again since, the legacy session is long gone
+	if state != nil {
+		return nil
+	}
+	state = memory.NewState(legacy.VcId)
+	state.Discord.SetStatus(memory.SourceRecording, nil)
+	if legacy.R20Id != "" {
+		state.R20Id, state.RequestedR20Id = legacy.R20Id, legacy.R20Id
+		state.Roll20.SetStatus(memory.SourceRecording, nil)
+	}
+	if err = state.Transition(memory.Recording); err != nil {
+		return err
+	}
+	slog.Info(fmt.Sprintf("[Reconciler] :: Adopting the session of voice channel %s left by a previous version", legacy.VcId))
+	return r.memory.Save(ctx, legacy.VcId, *state)
+}
+
+// Track a session whose Discord recording is still running, or was interrupted.
+// The session is only saved, and true returned, if its phase or the status of a source changed
+func (r *Recorder) resume(ctx context.Context, key string, state *memory.State) (bool, error) {
+	phase, discord, roll20 := state.Phase, state.Discord.Status, state.Roll20.Status
+	if state.R20Id != "" {
+		recording, err := r.roll20Sync.IsRecording(ctx, state.R20Id)
+		switch {
+		case err != nil:
+			slog.Warn(fmt.Sprintf("[Reconciler] :: Could not probe Roll20 for session %s. Reason : %s", key, err.Error()))
+		case recording:
+			state.Roll20.SetStatus(memory.SourceRecording, nil)
+		default:
+			state.Roll20.SetStatus(memory.SourceFailed, fmt.Errorf("roll20 game %s isn't being recorded anymore", state.R20Id))
+		}
+	}
+	if state.Discord.Status != memory.SourceInterrupted {
+		state.Discord.SetStatus(memory.SourceRecording, nil)
+	}
+	// A paused session stays paused
+	if state.Phase != memory.Recording && state.Phase != memory.Paused {
+		if err := state.Transition(memory.Recording); err != nil {
+			return false, err
+		}
+	}
+	changed := state.Phase != phase || state.Discord.Status != discord || state.Roll20.Status != roll20
+	if changed {
+		if err := r.memory.Save(ctx, key, *state); err != nil {
+			return false, err
+		}
+	}
+	r.watch(state)
+	return changed, nil
+}
+
+// End a session that cannot go on, stopping whatever source may still be recording
+func (r *Recorder) fail(ctx context.Context, key string, state *memory.State, reason string) error {
+	if state.R20Id != "" && state.Roll20.Status == memory.SourceRecording {
+		if _, err := r.roll20Sync.Stop(ctx, state.R20Id); err != nil {
+			slog.Warn(fmt.Sprintf("[Reconciler] :: Could not stop Roll20 for session %s. Reason : %s", key, err.Error()))
+		}
+		state.Roll20.SetStatus(memory.SourceStopped, nil)
+	}
+	if state.Discord.Status == memory.SourceRecording {
+		if _, err := r.pandora.Stop(ctx, state.PandoraInstance, state.VcId); err != nil {
+			slog.Warn(fmt.Sprintf("[Reconciler] :: Could not stop Pandora for session %s. Reason : %s", key, err.Error()))
+		}
+		state.Discord.SetStatus(memory.SourceStopped, nil)
+	}
+	r.unwatch(state.VcId)
+	if err := state.Transition(memory.Failed); err != nil {
+		return err
+	}
+	if err := r.memory.Delete(ctx, key); err != nil {
+		return err
+	}
+	r.emit(newEvent(events.Failed, state, reason))
+	return nil
+}
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"record-orchestrator/pkg/events"
+	"record-orchestrator/pkg/memory"
+	test_utils "record-orchestrator/test-utils"
+	"testing"
+)
+
+func recordingState(vcId, r20Id string) *memory.State {
+	s := memory.NewState(vcId)
+	s.R20Id, s.RequestedR20Id = r20Id, r20Id
+	s.Discord.SetStatus(memory.SourceRecording, nil)
+	if r20Id != "" {
+		s.Roll20.SetStatus(memory.SourceRecording, nil)
+	}
+	_ = s.Transition(memory.Recording)
+	return s
+}
+
+// Expect a single reconciliation event with the given decision
+func expectDecision(evts *test_utils.MockEmitter, decision Decision) {
+	evts.EXPECT().Emit(mock.MatchedBy(func(e events.Event) bool {
+		return e.Kind == events.Reconciled && e.Decision == string(decision)
+	})).Return(nil).Once()
+}
+
+// Expect a single published event of the given kind
+func expectKind(evts *test_utils.MockEmitter, kind events.Kind) {
+	evts.EXPECT().Emit(mock.MatchedBy(func(e events.Event) bool {
+		return e.Kind == kind
+	})).Return(nil).Once()
+}
+
+func TestRecorder_ReconcileResume(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	evts := test_utils.MockEmitter{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{Events: &evts})
+	mem.EXPECT().Keys(mock.Anything).Return([]string{"1"}, nil)
+	mem.EXPECT().Get(mock.Anything, "1").Return(recordingState("1", "2"), nil)
+	mem.EXPECT().Save(mock.Anything, "1", mock.MatchedBy(func(s memory.State) bool {
+		return s.Phase == memory.Recording && s.Roll20.Status == memory.SourceFailed
+	})).Return(nil)
+	pandora.On("IsRecording", mock.Anything, "", "1").Return(true, nil)
+	r20Rec.On("IsRecording", mock.Anything, "2").Return(false, nil)
+	expectDecision(&evts, Resumed)
+
+	left, err := recorder.Reconcile(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, left)
+	mem.AssertExpectations(t)
+	evts.AssertExpectations(t)
+	pandora.AssertNotCalled(t, "Stop", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRecorder_ReconcileStartingSessionResumed(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	evts := test_utils.MockEmitter{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{Events: &evts})
+	mem.EXPECT().Keys(mock.Anything).Return([]string{"1"}, nil)
+	// Crashed right after Pandora started
+	mem.EXPECT().Get(mock.Anything, "1").Return(memory.NewState("1"), nil)
+	mem.EXPECT().Save(mock.Anything, "1", mock.MatchedBy(func(s memory.State) bool {
+		return s.Phase == memory.Recording && s.Discord.Status == memory.SourceRecording
+	})).Return(nil)
+	pandora.On("IsRecording", mock.Anything, "", "1").Return(true, nil)
+	expectDecision(&evts, Resumed)
+
+	left, err := recorder.Reconcile(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, left)
+	mem.AssertExpectations(t)
+	evts.AssertExpectations(t)
+}
+
+func TestRecorder_ReconcileMarkFailed(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	evts := test_utils.MockEmitter{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{Events: &evts})
+	mem.EXPECT().Keys(mock.Anything).Return([]string{"1"}, nil)
+	mem.EXPECT().Get(mock.Anything, "1").Return(recordingState("1", "2"), nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil)
+	pandora.On("IsRecording", mock.Anything, "", "1").Return(false, nil)
+	// Roll20 is still recording and must be stopped
+	r20Rec.On("Stop", mock.Anything, "2").Return("2.ogg", nil)
+	expectKind(&evts, events.Failed)
+	expectDecision(&evts, MarkedFailed)
+
+	left, err := recorder.Reconcile(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, left)
+	mem.AssertExpectations(t)
+	r20Rec.AssertExpectations(t)
+	evts.AssertExpectations(t)
+	pandora.AssertNotCalled(t, "Stop", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Not knowing whether Pandora is recording isn't a reason to end the session.
+// Only the sessions left as is are retried, and the ones tracked as they were aren't worth a decision
+func TestRecorder_ReconcileProbeFailed(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	evts := test_utils.MockEmitter{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{Events: &evts})
+	mem.EXPECT().Keys(mock.Anything).Return([]string{"1", "2"}, nil).Once()
+	mem.EXPECT().Get(mock.Anything, "1").Return(recordingState("1", "3"), nil)
+	mem.EXPECT().Get(mock.Anything, "2").Return(recordingState("2", ""), nil).Once()
+	pandora.On("IsRecording", mock.Anything, "", "1").Return(false, fmt.Errorf("timeout")).Once()
+	pandora.On("IsRecording", mock.Anything, "", "2").Return(true, nil).Once()
+
+	left, err := recorder.Reconcile(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, []string{"1"}, left)
+	mem.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+	mem.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	pandora.AssertNotCalled(t, "Stop", mock.Anything, mock.Anything, mock.Anything)
+	evts.AssertNotCalled(t, "Emit", mock.Anything)
+
+	pandora.On("IsRecording", mock.Anything, "", "1").Return(true, nil).Once()
+	r20Rec.On("IsRecording", mock.Anything, "3").Return(true, nil).Once()
+	left, err = recorder.ReconcileSessions(context.Background(), left)
+	assert.NoError(t, err)
+	assert.Empty(t, left)
+	mem.AssertExpectations(t)
+	pandora.AssertExpectations(t)
+	evts.AssertNotCalled(t, "Emit", mock.Anything)
+}
+
+func TestRecorder_ReconcileFinishStop(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	evts := test_utils.MockEmitter{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{Events: &evts})
+	state := recordingState("1", "")
+	_ = state.Transition(memory.Stopping)
+	mem.EXPECT().Keys(mock.Anything).Return([]string{"1"}, nil)
+	mem.EXPECT().Get(mock.Anything, "1").Return(state, nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil)
+	pandora.On("Stop", mock.Anything, "", "1").Return([]string{"a"}, nil)
+	expectKind(&evts, events.Stopped)
+	evts.EXPECT().Emit(mock.MatchedBy(func(e events.Event) bool {
+		return e.Decision == string(Finished) && e.Phase == string(memory.Stopped) && e.DiscordKeys[0] == "a"
+	})).Return(nil).Once()
+
+	left, err := recorder.Reconcile(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, left)
+	mem.AssertExpectations(t)
+	evts.AssertExpectations(t)
+}
+
+func TestRecorder_ReconcileClearEnded(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	evts := test_utils.MockEmitter{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{Events: &evts})
+	mem.EXPECT().Keys(mock.Anything).Return([]string{"1", "2"}, nil)
+	mem.EXPECT().Get(mock.Anything, "1").Return(&memory.State{VcId: "1", Phase: memory.Stopped}, nil)
+	// Deleted between the listing and the reconciliation
+	mem.EXPECT().Get(mock.Anything, "2").Return(nil, nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil)
+	expectDecision(&evts, Cleared)
+
+	left, err := recorder.Reconcile(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, left)
+	mem.AssertExpectations(t)
+	evts.AssertExpectations(t)
+	pandora.AssertNotCalled(t, "IsRecording", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// The session left by a previous version is reconciled like any other
+func TestRecorder_ReconcileAdoptsLegacySession(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	legacy := test_utils.MockLegacyStore{}
+	evts := test_utils.MockEmitter{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{Events: &evts, Legacy: &legacy})
+	var state *memory.State
+	legacy.EXPECT().Get(mock.Anything).Return(&memory.LegacySession{VcId: "1", R20Id: "2"}, nil)
+	legacy.EXPECT().Delete(mock.Anything).Return(nil).Once()
+	mem.EXPECT().Get(mock.Anything, "1").RunAndReturn(func(context.Context, string) (*memory.State, error) {
+		return state, nil
+	})
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Run(func(ctx context.Context, key string, value memory.State) {
+		state = &value
+	}).Return(nil)
+	mem.EXPECT().Keys(mock.Anything).Return([]string{"1"}, nil)
+	pandora.On("IsRecording", mock.Anything, "", "1").Return(false, nil)
+	pandora.On("Stop", mock.Anything, "", "1").Return([]string{}, nil)
+	r20Rec.On("Stop", mock.Anything, "2").Return("", nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil)
+	expectDecision(&evts, MarkedFailed)
+	expectKind(&evts, events.Failed)
+
+	left, err := recorder.Reconcile(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, left)
+	assert.Equal(t, "2", state.R20Id)
+	legacy.AssertExpectations(t)
+	evts.AssertExpectations(t)
+	// The Roll20 recording of the legacy session isn't left running
+	r20Rec.AssertCalled(t, "Stop", mock.Anything, "2")
+}
+
+// A voice channel recorded again since doesn't adopt the legacy session
+func TestRecorder_ReconcileLegacySessionSuperseded(t *testing.T) {
+	mem := test_utils.MockStateStore{}
+	legacy := test_utils.MockLegacyStore{}
+	recorder := NewRecorder(nil, nil, &mem, RecorderOpt{Legacy: &legacy})
+	legacy.EXPECT().Get(mock.Anything).Return(&memory.LegacySession{VcId: "1"}, nil)
+	legacy.EXPECT().Delete(mock.Anything).Return(nil).Once()
+	mem.EXPECT().Get(mock.Anything, "1").Return(&memory.State{VcId: "1", Phase: memory.Stopped}, nil).Once()
+	mem.EXPECT().Keys(mock.Anything).Return([]string{}, nil)
+
+	left, err := recorder.Reconcile(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, left)
+	mem.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+	legacy.AssertExpectations(t)
+}
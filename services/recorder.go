@@ -1,113 +1,507 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"record-orchestrator/pkg/events"
 	"record-orchestrator/pkg/memory"
+	"record-orchestrator/pkg/mixer"
 	"record-orchestrator/pkg/pandora"
 	roll20_sync "record-orchestrator/pkg/roll20-sync"
 	pb "record-orchestrator/proto"
+	"sync"
+	"time"
 )
 
+// ErrUnknownSession is returned when a session id doesn't match any active session
+var ErrUnknownSession = errors.New("[Recorder] :: no active session with id")
+
+// ErrNoSession is returned when no session is recording the voice channel a call refers to
+var ErrNoSession = errors.New("[Recorder] :: no session")
+
+// ErrInvalidArgument is returned when a call misses a required parameter, or one of them is wrong
+var ErrInvalidArgument = errors.New("invalid argument")
+
+type RecorderOpt struct {
+	// Where the lifecycle events are published. Events are discarded if nil
+	Events events.Emitter
+	// Receives every lifecycle event, to notify the webhooks interested in it. Disabled if nil
+	Webhooks events.Emitter
+	// Where ended sessions are kept. History is disabled if nil
+	History memory.HistoryStore
+	// Recordings are pruned from the history once started this long ago, by PruneHistory. Kept forever if 0
+	HistoryRetention time.Duration
+	// Sessions are stopped automatically once they lasted this long, unless
+	// started with another maximum duration. No limit if 0
+	MaxDuration time.Duration
+	// Mixes the tracks of the sessions once stopped, when requested. Mixing is disabled if nil
+	Mixer mixer.AudioMixer
+	// Interval between two checks of a mix job progress, DEFAULT_MIX_POLL_INTERVAL if 0
+	MixPollInterval time.Duration
+	// Mix jobs still unfinished this long after their session stopped are given up, DEFAULT_MIX_TIMEOUT if 0
+	MixTimeout time.Duration
+	// Where the replies of the calls made with an idempotency key are kept. Keys are ignored if nil
+	Replies memory.ReplyStore
+	// Session left by a version predating the sessions namespace, adopted when reconciling. Ignored if nil
+	Legacy memory.LegacyStore
+}
+
 type Recorder struct {
 	pandora    pandora.DiscordRecorder
 	roll20Sync roll20_sync.R20Recorder
-	memory     memory.StateStore
-	stateKey   string
+	// Sessions, each one stored under the voice channel it is recording
+	memory   memory.StateStore
+	history  memory.HistoryStore
+	events   events.Emitter
+	webhooks events.Emitter
+	// In-process subscribers to the lifecycle events
+	watchers *events.Broker
+	// Per voice channel locks, preventing two concurrent calls from racing on the same session,
+	// and per idempotency key locks. A lock is forgotten once nobody holds or waits for it
+	locksMu sync.Mutex
+	locks   map[string]*vcLock
+	// Default maximum duration of a session
+	maxDuration time.Duration
+	// Per voice channel timers, stopping sessions reaching their maximum duration
+	watchdogs sync.Map
+	mixer     mixer.AudioMixer
+	// Interval between two checks of a mix job progress
+	mixPollInterval time.Duration
+	mixTimeout      time.Duration
+	replies         memory.ReplyStore
+	legacy          memory.LegacyStore
+	// How long recordings are kept in the history
+	historyRetention time.Duration
 }
 
-func NewRecorder(pandora pandora.DiscordRecorder, r20 roll20_sync.R20Recorder, memory memory.StateStore) *Recorder {
+func NewRecorder(pandora pandora.DiscordRecorder, r20 roll20_sync.R20Recorder, memory memory.StateStore, opt RecorderOpt) *Recorder {
+	if opt.Events == nil {
+		opt.Events = events.Discard{}
+	}
+	if opt.MixPollInterval <= 0 {
+		opt.MixPollInterval = DEFAULT_MIX_POLL_INTERVAL
+	}
+	if opt.MixTimeout <= 0 {
+		opt.MixTimeout = DEFAULT_MIX_TIMEOUT
+	}
 	return &Recorder{
-		pandora:    pandora,
-		roll20Sync: r20,
-		memory:     memory,
-		stateKey:   "recorder-state",
+		pandora:          pandora,
+		roll20Sync:       r20,
+		memory:           memory,
+		history:          opt.History,
+		historyRetention: opt.HistoryRetention,
+		events:           opt.Events,
+		webhooks:         opt.Webhooks,
+		watchers:         events.NewBroker(),
+		locks:            make(map[string]*vcLock),
+		maxDuration:      opt.MaxDuration,
+		mixer:            opt.Mixer,
+		mixPollInterval:  opt.MixPollInterval,
+		mixTimeout:       opt.MixTimeout,
+		replies:          opt.Replies,
+		legacy:           opt.Legacy,
 	}
 }
 
-func (r *Recorder) Start(payload *pb.StartRecordRequest) (*pb.StartRecordReply, error) {
+func (r *Recorder) Start(ctx context.Context, payload *pb.StartRecordRequest) (*pb.StartRecordReply, error) {
 	// Input sanity check
 	if payload.VoiceChannelId == "" {
-		return nil, fmt.Errorf("[Recorder] :: voice channel id is required but got %+v", payload)
+		return nil, fmt.Errorf("[Recorder] :: %w, voice channel id is required but got %+v", ErrInvalidArgument, payload)
+	}
+	if payload.GetMaxDurationMs() < 0 {
+		return nil, fmt.Errorf("[Recorder] :: %w, maximum duration cannot be negative but got %+v", ErrInvalidArgument, payload)
+	}
+	metadata, err := fromPbMetadata(payload.GetMetadata())
+	if err != nil {
+		return nil, err
 	}
-	// Check if we're already recording
-	state, err := r.memory.Get(r.stateKey)
+	defer r.lock(payload.VoiceChannelId)()
+	key := payload.VoiceChannelId
+
+	// A retried call must not fail because the original one succeeded
+	var replayed pb.StartRecordReply
+	found, err := r.replay(ctx, payload.GetIdempotencyKey(), OpStart, &replayed)
 	if err != nil {
 		return nil, err
 	}
-	if state != nil {
-		return nil, fmt.Errorf("[Recorder] :: already recording")
+	if found {
+		return &replayed, nil
 	}
 
-	state = &memory.State{
-		VcId:  "",
-		R20Id: "",
+	// Check if we're already recording this channel.
+	// A session that already ended can be safely replaced
+	state, err := r.memory.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if state != nil && state.IsActive() {
+		return nil, fmt.Errorf("[Recorder] :: already recording voice channel %s (%s)", payload.VoiceChannelId, state.Phase)
 	}
 
-	err = r.pandora.Start(payload.VoiceChannelId)
+	// Persist the session before starting anything, so that a crash
+	// halfway through leaves a trace of what was going on
+	state = memory.NewState(payload.VoiceChannelId)
+	state.Requester = payload.GetRequester()
+	state.RequestedR20Id = payload.GetRoll20GameId()
+	state.Metadata = metadata
+	state.Mix.Requested = payload.GetMix()
+	state.MaxDuration = r.maxDuration
+	if payload.GetMaxDurationMs() > 0 {
+		state.MaxDuration = time.Duration(payload.GetMaxDurationMs()) * time.Millisecond
+	}
+	err = r.memory.Save(ctx, key, *state)
 	if err != nil {
 		return nil, err
 	}
+	r.emit(newEvent(events.StartRequested, state, ""))
+
+	// Each successful step registers a compensation, undoing it if a later step fails.
+	// This way, a failed start never leaves anything recording. Compensations must
+	// still run when the caller gave up, which is also a reason for a step to fail
+	sg := newSaga("start")
+	detached := context.WithoutCancel(ctx)
+	sg.onRollback("memory", func() error {
+		return r.memory.Delete(detached, key)
+	})
+	stopPandora := func() error {
+		_, err := r.pandora.Stop(detached, state.PandoraInstance, payload.VoiceChannelId)
+		return err
+	}
+	state.Discord.RequestedAt = time.Now()
+	// Every later call is routed to the instance recording the voice channel
+	state.PandoraInstance, err = r.pandora.Start(ctx, payload.VoiceChannelId)
+	state.Discord.AckAt = time.Now()
+	if err != nil {
+		// The request may have reached Pandora before the caller gave up, or before the reply was due
+		if ctx.Err() != nil || errors.Is(err, pandora.ErrTimeout) {
+			sg.onRollback("pandora", stopPandora)
+		}
+		state.Discord.SetStatus(memory.SourceFailed, err)
+		return nil, r.rollback(ctx, sg, key, state, "pandora", err)
+	}
+	state.Discord.SetStatus(memory.SourceRecording, nil)
+	sg.onRollback("pandora", stopPandora)
+	// Saved right away, so that a crash from now on leaves the instance to probe and stop behind
+	if err = r.memory.Save(ctx, key, *state); err != nil {
+		return nil, r.rollback(ctx, sg, key, state, "memory", err)
+	}
+	r.emit(newEvent(events.DiscordAcknowledged, state, ""))
 	reply := pb.StartRecordReply{
-		Discord: true,
-		Roll20:  false,
+		Discord:   true,
+		Roll20:    false,
+		SessionId: state.Id,
 	}
-	state.VcId = payload.VoiceChannelId
-	//r.memory.Save(payload.VoiceChannelId, true)
 	// Roll20 is optional so we don't return an error if it's not provided
 	if payload.GetRoll20GameId() != "" {
-		err = r.roll20Sync.Start(payload.GetRoll20GameId())
+		stopRoll20 := func() error {
+			_, err := r.roll20Sync.Stop(detached, payload.GetRoll20GameId())
+			return err
+		}
+		state.Roll20.RequestedAt = time.Now()
+		err = r.roll20Sync.Start(ctx, payload.GetRoll20GameId())
+		state.Roll20.AckAt = time.Now()
+		// Roll20 failing is tolerated, but not the caller giving up
+		if err != nil && ctx.Err() != nil {
+			sg.onRollback("roll20", stopRoll20)
+			state.Roll20.SetStatus(memory.SourceFailed, err)
+			return nil, r.rollback(ctx, sg, key, state, "roll20", err)
+		}
 		if err != nil {
 			slog.Warn(fmt.Sprintf("[Recorder] :: Failed to start roll20 sync, continuing without it. Reason : %s", err.Error()))
-			return &reply, nil
+			state.Roll20.SetStatus(memory.SourceFailed, err)
+			r.emit(newEvent(events.Warning, state, fmt.Sprintf("could not start roll20 sync : %s", err.Error())))
+		} else {
+			sg.onRollback("roll20", stopRoll20)
+			reply.Roll20 = true
+			state.R20Id = payload.GetRoll20GameId()
+			state.Roll20.SetStatus(memory.SourceRecording, nil)
+			r.emit(newEvent(events.Roll20Attached, state, ""))
 		}
-		reply.Roll20 = true
-		state.R20Id = payload.GetRoll20GameId()
 	}
 
-	err = r.memory.Save(r.stateKey, *state)
+	// Every source started, we now know how far apart they are
+	state.Offsets, state.Origin = computeOffsets(state)
+	err = state.Transition(memory.Recording)
+	if err == nil {
+		err = r.memory.Save(ctx, key, *state)
+	}
 	if err != nil {
-		return nil, err
+		return nil, r.rollback(ctx, sg, key, state, "memory", err)
 	}
+	started := newEvent(events.Started, state, "")
+	started.Offsets = state.Offsets
+	r.emit(started)
+	r.watch(state)
+	r.remember(ctx, payload.GetIdempotencyKey(), OpStart, &reply)
 	return &reply, nil
 }
 
-func (r *Recorder) Stop(payload *pb.StopRecordRequest) (*pb.StopRecordReply, error) {
-	if payload.VoiceChannelId == "" {
-		return nil, fmt.Errorf("[Recorder] :: voice channel id is required but got %+v", payload)
-	}
-	state, err := r.memory.Get(r.stateKey)
+func (r *Recorder) Stop(ctx context.Context, payload *pb.StopRecordRequest) (*pb.StopRecordReply, error) {
+	// The session is gone once stopped, so a retried call is replayed before looking for it.
+	// A retry racing the original call waits for its reply, as the voice channel is unlocked before the reply is kept
+	defer r.lockIdempotencyKey(payload.GetIdempotencyKey())()
+	var replayed pb.StopRecordReply
+	found, err := r.replay(ctx, payload.GetIdempotencyKey(), OpStop, &replayed)
 	if err != nil {
 		return nil, err
 	}
-	if state == nil {
-		return nil, fmt.Errorf("[Recorder] :: not recording")
+	if found {
+		return &replayed, nil
 	}
-	if state.VcId != payload.VoiceChannelId || state.R20Id != payload.GetRoll20GameId() {
-		return nil, fmt.Errorf("[Recorder] :: Wrong recordings parameters, expected %+v, got %+v", state, payload)
+	key, err := r.locate(ctx, payload.GetSessionId(), payload.VoiceChannelId)
+	if err != nil {
+		return nil, err
 	}
-
-	ids, err := r.pandora.Stop(payload.VoiceChannelId)
+	reply, mix, err := r.stopSession(ctx, key, payload)
 	if err != nil {
 		return nil, err
 	}
+	// The voice channel is already unlocked, as the mixer may be slow
+	r.submitMix(ctx, mix, reply)
+	r.remember(ctx, payload.GetIdempotencyKey(), OpStop, reply)
+	return reply, nil
+}
+
+// Stop the session a stop request refers to, returning the mix job to submit, if any
+func (r *Recorder) stopSession(ctx context.Context, key string, payload *pb.StopRecordRequest) (*pb.StopRecordReply, *pendingMix, error) {
+	defer r.lock(key)()
+	state, err := r.memory.Get(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !isSession(state, payload.GetSessionId()) {
+		return nil, nil, fmt.Errorf("%w recording voice channel %s", ErrNoSession, key)
+	}
+	// Without a session id, the caller must repeat the parameters of the start, whether Roll20 started or not
+	if payload.GetSessionId() == "" && (state.VcId != payload.VoiceChannelId || state.RequestedR20Id != payload.GetRoll20GameId()) {
+		return nil, nil, fmt.Errorf("[Recorder] :: Wrong recordings parameters, expected %+v, got %+v : %w", state, payload, ErrInvalidArgument)
+	}
+	if payload.GetMix() {
+		state.Mix.Requested = true
+	}
+	return r.stop(ctx, key, state)
+}
+
+// Stop every source of an active session and end it. The mix job of the session, if any,
+// is returned to be submitted once the voice channel is unlocked
+func (r *Recorder) stop(ctx context.Context, key string, state *memory.State) (*pb.StopRecordReply, *pendingMix, error) {
+	var err error
+	r.unwatch(state.VcId)
+	// A previous stop attempt may have failed halfway, in which case
+	// we're resuming it instead of starting a new one
+	if state.Phase != memory.Stopping {
+		if err = state.Transition(memory.Stopping); err != nil {
+			return nil, nil, err
+		}
+		if err = r.memory.Save(ctx, key, *state); err != nil {
+			return nil, nil, err
+		}
+	}
+	r.emit(newEvent(events.StopRequested, state, ""))
+
+	// Pandora interrupted on its own already uploaded what it could
+	ids := state.DiscordKeys
+	if state.Discord.Status != memory.SourceInterrupted {
+		ids, err = r.pandora.Stop(ctx, state.PandoraInstance, state.VcId)
+		// Pandora may still be stopping if the caller gave up. The session stays
+		// stopping, for a retry or the reconciler to complete the stop
+		if err != nil && ctx.Err() != nil {
+			return nil, nil, err
+		}
+		if err != nil {
+			state.Discord.SetStatus(memory.SourceFailed, err)
+			r.save(ctx, key, state)
+			r.emit(newEvent(events.Warning, state, fmt.Sprintf("could not stop Pandora : %s", err.Error())))
+			return nil, nil, err
+		}
+		state.Discord.SetStatus(memory.SourceStopped, nil)
+	}
+	// The keys of the tracks aren't persisted anywhere else, so from
+	// now on the stop is completed even if the caller gives up
+	ctx = context.WithoutCancel(ctx)
+	uploaded := newEvent(events.TracksUploaded, state, "")
+	uploaded.DiscordKeys = ids
+	r.emit(uploaded)
 
 	r20Key := ""
-	if payload.GetRoll20GameId() != "" {
-		r20Key, err = r.roll20Sync.Stop(payload.GetRoll20GameId())
+	if state.R20Id != "" {
+		r20Key, err = r.roll20Sync.Stop(ctx, state.R20Id)
 		if err != nil {
 			slog.Warn(fmt.Sprintf("[Recorder] :: Failed to stop roll20 sync, continuing without it. Reason : %s", err.Error()))
+			state.Roll20.SetStatus(memory.SourceFailed, err)
+			r.emit(newEvent(events.Warning, state, fmt.Sprintf("could not stop roll20 sync : %s", err.Error())))
+		} else {
+			state.Roll20.SetStatus(memory.SourceStopped, nil)
 		}
 	}
 
-	err = r.memory.Delete(r.stateKey)
+	if err = state.Transition(memory.Stopped); err != nil {
+		return nil, nil, err
+	}
+	stoppedAt, _ := state.EnteredAt(memory.Stopped)
+	reply := &pb.StopRecordReply{
+		DiscordKeys: ids,
+		Roll20Key:   r20Key,
+		Offsets:     trackOffsets(state.Offsets, ids, r20Key),
+		Pauses:      toPauseIntervals(pauseOffsets(state, stoppedAt)),
+		Metadata:    toPbMetadata(state.Metadata),
+	}
+	recordId := r.archive(ctx, state, reply)
+	err = r.memory.Delete(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	stopped := newEvent(events.Stopped, state, "")
+	// Roll20 is optional, so the session still ends normally without it.
+	// So does a session whose Discord recording was interrupted, with what was uploaded
+	switch {
+	case state.Discord.Status == memory.SourceInterrupted:
+		stopped.Kind, stopped.Message = events.PartiallyStopped, fmt.Sprintf("discord was interrupted : %s", state.Discord.Error)
+	case state.Roll20.Status == memory.SourceFailed:
+		stopped.Kind, stopped.Message = events.PartiallyStopped, fmt.Sprintf("roll20 failed : %s", state.Roll20.Error)
+	}
+	stopped.DiscordKeys, stopped.Roll20Key, stopped.Offsets = reply.DiscordKeys, reply.Roll20Key, reply.Offsets
+	r.emit(stopped)
+
+	return reply, newPendingMix(recordId, state, reply.DiscordKeys, reply.Roll20Key, reply.Offsets), nil
+}
+
+// GetRecording returns the session currently active on a voice channel, if any
+func (r *Recorder) GetRecording(ctx context.Context, payload *pb.GetRecordingRequest) (*pb.GetRecordingReply, error) {
+	key, err := r.locate(ctx, payload.GetSessionId(), payload.VoiceChannelId)
+	if errors.Is(err, ErrUnknownSession) {
+		return &pb.GetRecordingReply{Recording: false}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	state, err := r.memory.Get(ctx, key)
 	if err != nil {
 		return nil, err
 	}
+	if !isSession(state, payload.GetSessionId()) {
+		return &pb.GetRecordingReply{Recording: false}, nil
+	}
 
-	// TODO :: Calculate offset for synchronisation
-	return &pb.StopRecordReply{
-		DiscordKeys: ids,
-		Roll20Key:   r20Key,
-	}, nil
+	startedAt := state.StartedAt()
+	reply := &pb.GetRecordingReply{
+		Recording:       true,
+		SessionId:       state.Id,
+		VoiceChannelId:  state.VcId,
+		Roll20GameId:    state.R20Id,
+		Discord:         toSourceStatus(state.Discord),
+		Roll20:          toSourceStatus(state.Roll20),
+		Phase:           string(state.Phase),
+		Metadata:        toPbMetadata(state.Metadata),
+		PandoraInstance: state.PandoraInstance,
+	}
+	if !startedAt.IsZero() {
+		reply.StartedAt = startedAt.UnixMilli()
+		reply.ElapsedMs = time.Since(startedAt).Milliseconds()
+	}
+	return reply, nil
+}
+
+// Find the key of the session a request refers to, by its id if there is one,
+// or else by its voice channel. Sessions are stored under their voice channel
+func (r *Recorder) locate(ctx context.Context, sessionId, vcId string) (string, error) {
+	if sessionId == "" {
+		if vcId == "" {
+			return "", fmt.Errorf("[Recorder] :: %w, voice channel id or session id is required", ErrInvalidArgument)
+		}
+		return vcId, nil
+	}
+	keys, err := r.memory.Keys(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, key := range keys {
+		state, err := r.memory.Get(ctx, key)
+		if err != nil {
+			return "", err
+		}
+		if state == nil || state.Id != sessionId {
+			continue
+		}
+		if vcId != "" && vcId != state.VcId {
+			return "", fmt.Errorf("[Recorder] :: %w, session %s is recording voice channel %s, not %s", ErrInvalidArgument, sessionId, state.VcId, vcId)
+		}
+		return key, nil
+	}
+	return "", fmt.Errorf("%w %s", ErrUnknownSession, sessionId)
+}
+
+// Whether state is an active session, and the one identified by sessionId if not empty.
+// The session may have ended between its lookup and the lock of its voice channel
+func isSession(state *memory.State, sessionId string) bool {
+	return state != nil && state.IsActive() && (sessionId == "" || state.Id == sessionId)
+}
+
+func toPauseIntervals(pauses []memory.PauseOffset) []*pb.PauseInterval {
+	intervals := make([]*pb.PauseInterval, 0, len(pauses))
+	for _, p := range pauses {
+		intervals = append(intervals, &pb.PauseInterval{Start: p.Start, End: p.End})
+	}
+	return intervals
+}
+
+func toSourceStatus(src memory.Source) *pb.SourceStatus {
+	return &pb.SourceStatus{
+		Status: string(src.Status),
+		Error:  src.Error,
+	}
+}
+
+// Roll back a start that failed at step. The session goes through the compensating
+// phase while the saga compensations are running, and ends up failed
+func (r *Recorder) rollback(ctx context.Context, sg *saga, key string, state *memory.State, step string, cause error) error {
+	if err := state.Transition(memory.Compensating); err == nil {
+		r.save(ctx, key, state)
+	}
+	r.emit(newEvent(events.Warning, state, fmt.Sprintf("start failed at step %s, rolling back : %s", step, cause.Error())))
+	sErr := sg.abort(step, cause)
+	_ = state.Transition(memory.Failed)
+	slog.Error(fmt.Sprintf("[Recorder] :: %s", sErr.Error()))
+	r.emit(newEvent(events.Failed, state, sErr.Error()))
+	return sErr
+}
+
+// Best effort save of a session state, used when we're already handling another error.
+// The state is saved even if the caller gave up, as giving up may be that error
+func (r *Recorder) save(ctx context.Context, key string, state *memory.State) {
+	if err := r.memory.Save(context.WithoutCancel(ctx), key, *state); err != nil {
+		slog.Warn(fmt.Sprintf("[Recorder] :: Could not save state of session %s : %s", key, err.Error()))
+	}
+}
+
+// Lock of the session of a voice channel
+type vcLock struct {
+	mu sync.Mutex
+	// Calls holding or waiting for the lock
+	refs int
+}
+
+// Lock the session of the voice channel vcId.
+// The returned function releases the lock
+func (r *Recorder) lock(vcId string) func() {
+	r.locksMu.Lock()
+	l, ok := r.locks[vcId]
+	if !ok {
+		l = &vcLock{}
+		r.locks[vcId] = l
+	}
+	l.refs++
+	r.locksMu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+		r.locksMu.Lock()
+		defer r.locksMu.Unlock()
+		if l.refs--; l.refs == 0 {
+			delete(r.locks, vcId)
+		}
+	}
 }
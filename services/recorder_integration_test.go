@@ -4,6 +4,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"github.com/dapr/go-sdk/client"
 	daprd "github.com/dapr/go-sdk/service/grpc"
@@ -53,14 +54,14 @@ func beforeAll() net.Listener {
 	}
 	daprClient := client.NewClientWithConnection(conn)
 	// State store
-	store := memory.NewMemory[State](daprClient, DEFAULT_STATE_STORE_ID)
+	store := memory.NewMemory[memory.State](daprClient, DEFAULT_STATE_STORE_ID, "recorder-sessions")
 	// Recorders themselves
 	pandora, err := pandora.NewPandora(daprClient, subServer, DEFAULT_PUBSUB_ID, pandora.PandoraOpt{})
 	if err != nil {
 		log.Fatalf("error creating dapr client: %v", err)
 	}
 	r20 := roll20_sync.NewRoll20Sync(daprClient, DEFAULT_ROLL20_ID)
-	recorder = NewRecorder(pandora, r20, store)
+	recorder = NewRecorder(pandora, r20, store, RecorderOpt{})
 
 	// Start the server
 	go func() {
@@ -72,40 +73,40 @@ func beforeAll() net.Listener {
 }
 
 func TestRecorder_PandoraOnly(t *testing.T) {
-	_, err := recorder.Start(&pb.StartRecordRequest{VoiceChannelId: TEST_CHANNEL})
+	_, err := recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: TEST_CHANNEL})
 	assert.NoError(t, err)
 
-	_, err = recorder.Start(&pb.StartRecordRequest{VoiceChannelId: TEST_CHANNEL})
+	_, err = recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: TEST_CHANNEL})
 	assert.Error(t, err)
 
 	time.Sleep(5 * time.Second)
-	_, err = recorder.Stop(&pb.StopRecordRequest{VoiceChannelId: TEST_CHANNEL})
+	_, err = recorder.Stop(context.Background(), &pb.StopRecordRequest{VoiceChannelId: TEST_CHANNEL})
 	assert.NoError(t, err)
 
 	time.Sleep(5 * time.Second)
-	_, err = recorder.Stop(&pb.StopRecordRequest{VoiceChannelId: TEST_CHANNEL})
+	_, err = recorder.Stop(context.Background(), &pb.StopRecordRequest{VoiceChannelId: TEST_CHANNEL})
 	assert.Error(t, err)
 }
 
 func TestRecorder_PandoraAndSyncer(t *testing.T) {
-	_, err := recorder.Start(&pb.StartRecordRequest{VoiceChannelId: TEST_CHANNEL, Roll20GameId: TEST_ROLL20_ID})
+	_, err := recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: TEST_CHANNEL, Roll20GameId: TEST_ROLL20_ID})
 	assert.NoError(t, err)
 
-	_, err = recorder.Start(&pb.StartRecordRequest{VoiceChannelId: TEST_CHANNEL, Roll20GameId: TEST_ROLL20_ID})
+	_, err = recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: TEST_CHANNEL, Roll20GameId: TEST_ROLL20_ID})
 	assert.Error(t, err)
 
 	// Wrong parameters
-	_, err = recorder.Stop(&pb.StopRecordRequest{VoiceChannelId: "1", Roll20GameId: TEST_ROLL20_ID})
+	_, err = recorder.Stop(context.Background(), &pb.StopRecordRequest{VoiceChannelId: "1", Roll20GameId: TEST_ROLL20_ID})
 	assert.Error(t, err)
 
-	_, err = recorder.Stop(&pb.StopRecordRequest{VoiceChannelId: TEST_CHANNEL})
+	_, err = recorder.Stop(context.Background(), &pb.StopRecordRequest{VoiceChannelId: TEST_CHANNEL})
 	assert.Error(t, err)
 
 	time.Sleep(5 * time.Second)
-	_, err = recorder.Stop(&pb.StopRecordRequest{VoiceChannelId: TEST_CHANNEL, Roll20GameId: TEST_ROLL20_ID})
+	_, err = recorder.Stop(context.Background(), &pb.StopRecordRequest{VoiceChannelId: TEST_CHANNEL, Roll20GameId: TEST_ROLL20_ID})
 	assert.NoError(t, err)
 
-	_, err = recorder.Stop(&pb.StopRecordRequest{VoiceChannelId: TEST_CHANNEL, Roll20GameId: TEST_ROLL20_ID})
+	_, err = recorder.Stop(context.Background(), &pb.StopRecordRequest{VoiceChannelId: TEST_CHANNEL, Roll20GameId: TEST_ROLL20_ID})
 	assert.Error(t, err)
 }
 
@@ -1,25 +1,31 @@
 package services
 
 import (
+	"context"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"record-orchestrator/pkg/memory"
+	"record-orchestrator/pkg/pandora"
 	pb "record-orchestrator/proto"
 	test_utils "record-orchestrator/test-utils"
 	"testing"
+	"time"
 )
 
 func TestRecorder_StartOnlyPandora(t *testing.T) {
 	pandora := test_utils.MockDiscordRecorder{}
 	r20Rec := test_utils.MockR20Recorder{}
 	mem := test_utils.MockStateStore{}
-	recorder := NewRecorder(&pandora, &r20Rec, &mem)
-	pandora.On("Start", "1").Return(nil)
-	mem.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
-	mem.EXPECT().Get(mock.Anything).Return(nil, nil)
-	ret, err := recorder.Start(&pb.StartRecordRequest{VoiceChannelId: "1"})
-	assert.Equal(t, &pb.StartRecordReply{Discord: true, Roll20: false}, ret)
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	pandora.On("Start", mock.Anything, "1").Return("", nil)
+	mem.EXPECT().Save(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mem.EXPECT().Get(mock.Anything, mock.Anything).Return(nil, nil)
+	ret, err := recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: "1"})
+	assert.Equal(t, &pb.StartRecordReply{Discord: true, Roll20: false, SessionId: ret.GetSessionId()}, ret)
+	assert.NotEmpty(t, ret.GetSessionId())
 	pandora.AssertExpectations(t)
-	r20Rec.AssertNotCalled(t, "Start", mock.Anything)
+	r20Rec.AssertNotCalled(t, "Start", mock.Anything, mock.Anything)
 	if err != nil {
 		t.Error(err)
 	}
@@ -29,16 +35,463 @@ func TestRecorder_StartPandoraAndRoll20(t *testing.T) {
 	pandora := test_utils.MockDiscordRecorder{}
 	r20Rec := test_utils.MockR20Recorder{}
 	mem := test_utils.MockStateStore{}
-	recorder := NewRecorder(&pandora, &r20Rec, &mem)
-	pandora.On("Start", "1").Return(nil)
-	r20Rec.On("Start", "2").Return(nil)
-	mem.EXPECT().Save(mock.Anything, mock.Anything).Return(nil)
-	mem.EXPECT().Get(mock.Anything).Return(nil, nil)
-	ret, err := recorder.Start(&pb.StartRecordRequest{VoiceChannelId: "1", Roll20GameId: "2"})
-	assert.Equal(t, &pb.StartRecordReply{Discord: true, Roll20: true}, ret)
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	pandora.On("Start", mock.Anything, "1").Return("", nil)
+	r20Rec.On("Start", mock.Anything, "2").Return(nil)
+	mem.EXPECT().Save(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mem.EXPECT().Get(mock.Anything, mock.Anything).Return(nil, nil)
+	ret, err := recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: "1", Roll20GameId: "2"})
+	assert.Equal(t, &pb.StartRecordReply{Discord: true, Roll20: true, SessionId: ret.GetSessionId()}, ret)
 	pandora.AssertExpectations(t)
 	r20Rec.AssertExpectations(t)
 	if err != nil {
 		t.Error(err)
 	}
 }
+
+func TestRecorder_StartAlreadyRecordingChannel(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	mem.EXPECT().Get(mock.Anything, "1").Return(&memory.State{VcId: "1", Phase: memory.Recording}, nil)
+	_, err := recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: "1"})
+	assert.Error(t, err)
+	pandora.AssertNotCalled(t, "Start", mock.Anything, mock.Anything)
+}
+
+func TestRecorder_StartReplacesEndedSession(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	mem.EXPECT().Get(mock.Anything, "1").Return(&memory.State{VcId: "1", Phase: memory.Failed}, nil)
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Return(nil)
+	pandora.On("Start", mock.Anything, "1").Return("", nil)
+	_, err := recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: "1"})
+	assert.NoError(t, err)
+}
+
+func TestRecorder_StartConcurrentChannels(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	// Channel 1 is already being recorded, channel 2 is free
+	mem.EXPECT().Get(mock.Anything, "1").Return(&memory.State{VcId: "1", Phase: memory.Recording}, nil).Maybe()
+	mem.EXPECT().Get(mock.Anything, "2").Return(nil, nil)
+	mem.EXPECT().Save(mock.Anything, "2", mock.Anything).Return(nil)
+	pandora.On("Start", mock.Anything, "2").Return("", nil)
+	ret, err := recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.StartRecordReply{Discord: true, Roll20: false, SessionId: ret.GetSessionId()}, ret)
+	pandora.AssertExpectations(t)
+	mem.AssertExpectations(t)
+}
+
+// Locks of the voice channels must not pile up once released
+func TestRecorder_LockForgotten(t *testing.T) {
+	recorder := NewRecorder(&test_utils.MockDiscordRecorder{}, &test_utils.MockR20Recorder{}, &test_utils.MockStateStore{}, RecorderOpt{})
+	unlock := recorder.lock("1")
+	locked := make(chan struct{})
+	go func() {
+		defer recorder.lock("1")()
+		close(locked)
+	}()
+	// Still held by the waiting call
+	unlock()
+	<-locked
+	assert.Eventually(t, func() bool {
+		recorder.locksMu.Lock()
+		defer recorder.locksMu.Unlock()
+		return len(recorder.locks) == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestRecorder_StartLifecycle(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	var saved []memory.State
+	mem.EXPECT().Get(mock.Anything, mock.Anything).Return(nil, nil)
+	mem.EXPECT().Save(mock.Anything, mock.Anything, mock.Anything).Run(func(ctx context.Context, key string, value memory.State) {
+		saved = append(saved, value)
+	}).Return(nil)
+	pandora.On("Start", mock.Anything, "1").Return("a", nil)
+	r20Rec.On("Start", mock.Anything, "2").Return(nil)
+	_, err := recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: "1", Roll20GameId: "2"})
+	assert.NoError(t, err)
+	// The session must be persisted before anything is started
+	assert.Len(t, saved, 3)
+	assert.Equal(t, memory.Starting, saved[0].Phase)
+	// And as soon as Pandora acknowledged, along with the instance recording
+	assert.Equal(t, memory.Starting, saved[1].Phase)
+	assert.Equal(t, "a", saved[1].PandoraInstance)
+	assert.Equal(t, memory.SourceRecording, saved[1].Discord.Status)
+	assert.Equal(t, memory.Recording, saved[2].Phase)
+	assert.Equal(t, memory.SourceRecording, saved[2].Discord.Status)
+	assert.Equal(t, memory.SourceRecording, saved[2].Roll20.Status)
+	assert.Len(t, saved[2].Transitions, 2)
+	assert.Contains(t, saved[2].Offsets, SourceDiscord)
+	assert.Contains(t, saved[2].Offsets, SourceRoll20)
+}
+
+func TestRecorder_StopRecordedChannel(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	mem.EXPECT().Get(mock.Anything, "2").Return(&memory.State{VcId: "2", Phase: memory.Recording, Offsets: map[string]int64{SourceDiscord: 0}}, nil)
+	mem.EXPECT().Save(mock.Anything, "2", mock.MatchedBy(func(s memory.State) bool {
+		return s.Phase == memory.Stopping
+	})).Return(nil)
+	mem.EXPECT().Delete(mock.Anything, "2").Return(nil)
+	pandora.On("Stop", mock.Anything, "", "2").Return([]string{"a"}, nil)
+	ret, err := recorder.Stop(context.Background(), &pb.StopRecordRequest{VoiceChannelId: "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a"}, ret.DiscordKeys)
+	assert.Equal(t, map[string]int64{"a": 0}, ret.Offsets)
+	mem.AssertExpectations(t)
+}
+
+// Roll20 failed to start, but the caller still repeats the parameters of the start
+func TestRecorder_StopWithoutRoll20(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	state := recordingState("1", "")
+	state.RequestedR20Id = "2"
+	state.Roll20.SetStatus(memory.SourceFailed, fmt.Errorf("roll20 down"))
+	mem.EXPECT().Get(mock.Anything, "1").Return(state, nil)
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Return(nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil)
+	pandora.On("Stop", mock.Anything, "", "1").Return([]string{"a"}, nil)
+
+	_, err := recorder.Stop(context.Background(), &pb.StopRecordRequest{VoiceChannelId: "1", Roll20GameId: "3"})
+	assert.ErrorContains(t, err, "Wrong recordings parameters")
+	ret, err := recorder.Stop(context.Background(), &pb.StopRecordRequest{VoiceChannelId: "1", Roll20GameId: "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a"}, ret.DiscordKeys)
+	r20Rec.AssertNotCalled(t, "Stop", mock.Anything, mock.Anything)
+}
+
+func TestRecorder_StopStartingSession(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	mem.EXPECT().Get(mock.Anything, "1").Return(memory.NewState("1"), nil)
+	_, err := recorder.Stop(context.Background(), &pb.StopRecordRequest{VoiceChannelId: "1"})
+	var tErr *memory.ErrIllegalTransition
+	assert.ErrorAs(t, err, &tErr)
+	pandora.AssertNotCalled(t, "Stop", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRecorder_StartRoll20Failure(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	pandora.On("Start", mock.Anything, "1").Return("", nil)
+	r20Rec.On("Start", mock.Anything, "2").Return(fmt.Errorf("roll20 down"))
+	mem.EXPECT().Get(mock.Anything, mock.Anything).Return(nil, nil)
+	var last memory.State
+	mem.EXPECT().Save(mock.Anything, mock.Anything, mock.Anything).Run(func(ctx context.Context, key string, value memory.State) {
+		last = value
+	}).Return(nil)
+	ret, err := recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: "1", Roll20GameId: "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.StartRecordReply{Discord: true, Roll20: false, SessionId: ret.GetSessionId()}, ret)
+	// The session must still be saved, without Roll20
+	assert.Equal(t, memory.Recording, last.Phase)
+	assert.Equal(t, "", last.R20Id)
+	assert.Equal(t, memory.SourceFailed, last.Roll20.Status)
+}
+
+func TestRecorder_StartCompensateOnSaveFailure(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	pandora.On("Start", mock.Anything, "1").Return("", nil)
+	pandora.On("Stop", mock.Anything, "", "1").Return([]string{}, nil)
+	r20Rec.On("Start", mock.Anything, "2").Return(nil)
+	r20Rec.On("Stop", mock.Anything, "2").Return("2.ogg", nil)
+	mem.EXPECT().Get(mock.Anything, mock.Anything).Return(nil, nil)
+	// Only the saves made while starting succeed
+	mem.EXPECT().Save(mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+	mem.EXPECT().Save(mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("store down"))
+	mem.EXPECT().Delete(mock.Anything, mock.Anything).Return(nil)
+	_, err := recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: "1", Roll20GameId: "2"})
+	var sErr *SagaError
+	assert.ErrorAs(t, err, &sErr)
+	assert.Equal(t, "memory", sErr.Step)
+	assert.Equal(t, []string{"roll20", "pandora", "memory"}, sErr.Compensations)
+	assert.Empty(t, sErr.FailedCompensations)
+	pandora.AssertExpectations(t)
+	r20Rec.AssertExpectations(t)
+	mem.AssertExpectations(t)
+}
+
+func TestRecorder_StartPandoraFailure(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	pandora.On("Start", mock.Anything, "1").Return("", fmt.Errorf("timeout"))
+	mem.EXPECT().Get(mock.Anything, mock.Anything).Return(nil, nil)
+	mem.EXPECT().Save(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil)
+	_, err := recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: "1"})
+	var sErr *SagaError
+	assert.ErrorAs(t, err, &sErr)
+	assert.Equal(t, "pandora", sErr.Step)
+	// No stale state must be left behind
+	mem.AssertExpectations(t)
+	r20Rec.AssertNotCalled(t, "Start", mock.Anything, mock.Anything)
+}
+
+func TestRecorder_StartCompensationFailure(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	pandora.On("Start", mock.Anything, "1").Return("", nil)
+	pandora.On("Stop", mock.Anything, "", "1").Return(nil, fmt.Errorf("timeout"))
+	mem.EXPECT().Get(mock.Anything, mock.Anything).Return(nil, nil)
+	mem.EXPECT().Save(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	mem.EXPECT().Save(mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("store down"))
+	mem.EXPECT().Delete(mock.Anything, mock.Anything).Return(nil)
+	_, err := recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: "1"})
+	var sErr *SagaError
+	assert.ErrorAs(t, err, &sErr)
+	assert.Equal(t, []string{"memory"}, sErr.Compensations)
+	assert.Equal(t, []string{"pandora"}, sErr.FailedCompensations)
+}
+
+func TestRecorder_GetRecording(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	state := memory.NewState("1")
+	state.R20Id = "2"
+	state.Discord.SetStatus(memory.SourceRecording, nil)
+	state.Roll20.SetStatus(memory.SourceFailed, fmt.Errorf("roll20 down"))
+	_ = state.Transition(memory.Recording)
+	mem.EXPECT().Get(mock.Anything, "1").Return(state, nil)
+
+	ret, err := recorder.GetRecording(context.Background(), &pb.GetRecordingRequest{VoiceChannelId: "1"})
+	assert.NoError(t, err)
+	assert.True(t, ret.Recording)
+	assert.Equal(t, "2", ret.Roll20GameId)
+	assert.Equal(t, string(memory.Recording), ret.Phase)
+	assert.Equal(t, string(memory.SourceRecording), ret.Discord.Status)
+	assert.Equal(t, "roll20 down", ret.Roll20.Error)
+	assert.NotZero(t, ret.StartedAt)
+	assert.GreaterOrEqual(t, ret.ElapsedMs, int64(0))
+}
+
+func TestRecorder_GetRecordingNoSession(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	mem.EXPECT().Get(mock.Anything, "1").Return(nil, nil)
+
+	ret, err := recorder.GetRecording(context.Background(), &pb.GetRecordingRequest{VoiceChannelId: "1"})
+	assert.NoError(t, err)
+	assert.False(t, ret.Recording)
+}
+
+func TestRecorder_StopBySessionId(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	state := recordingState("1", "2")
+	mem.EXPECT().Keys(mock.Anything).Return([]string{"3", "1"}, nil)
+	mem.EXPECT().Get(mock.Anything, "3").Return(recordingState("3", ""), nil)
+	mem.EXPECT().Get(mock.Anything, "1").Return(state, nil)
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Return(nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil)
+	pandora.On("Stop", mock.Anything, "", "1").Return([]string{"a"}, nil)
+	r20Rec.On("Stop", mock.Anything, "2").Return("2.ogg", nil)
+
+	// The session id is enough, no need to repeat the parameters of the start
+	ret, err := recorder.Stop(context.Background(), &pb.StopRecordRequest{SessionId: state.Id})
+	assert.NoError(t, err)
+	assert.Equal(t, "2.ogg", ret.Roll20Key)
+	pandora.AssertExpectations(t)
+}
+
+func TestRecorder_StopUnknownSession(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	state := recordingState("1", "")
+	mem.EXPECT().Keys(mock.Anything).Return([]string{"1"}, nil)
+	mem.EXPECT().Get(mock.Anything, "1").Return(state, nil)
+
+	_, err := recorder.Stop(context.Background(), &pb.StopRecordRequest{SessionId: "unknown"})
+	assert.ErrorIs(t, err, ErrUnknownSession)
+	// The session and the voice channel must agree
+	_, err = recorder.Stop(context.Background(), &pb.StopRecordRequest{SessionId: state.Id, VoiceChannelId: "2"})
+	assert.Error(t, err)
+	_, err = recorder.Stop(context.Background(), &pb.StopRecordRequest{})
+	assert.Error(t, err)
+	pandora.AssertNotCalled(t, "Stop", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRecorder_GetRecordingBySessionId(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	state := recordingState("1", "")
+	mem.EXPECT().Keys(mock.Anything).Return([]string{"1"}, nil)
+	mem.EXPECT().Get(mock.Anything, "1").Return(state, nil)
+
+	ret, err := recorder.GetRecording(context.Background(), &pb.GetRecordingRequest{SessionId: state.Id})
+	assert.NoError(t, err)
+	assert.True(t, ret.Recording)
+	assert.Equal(t, state.Id, ret.SessionId)
+	assert.Equal(t, "1", ret.VoiceChannelId)
+
+	// An ended session isn't found anymore
+	ret, err = recorder.GetRecording(context.Background(), &pb.GetRecordingRequest{SessionId: "ended"})
+	assert.NoError(t, err)
+	assert.False(t, ret.Recording)
+}
+
+// The caller gave up while Pandora was starting, which may still start afterward
+func TestRecorder_StartCancelled(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	ctx, cancel := context.WithCancel(context.Background())
+	mem.EXPECT().Get(mock.Anything, "1").Return(nil, nil)
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Return(nil)
+	pandora.On("Start", mock.Anything, "1").Run(func(mock.Arguments) { cancel() }).Return("", context.Canceled)
+	// Compensations aren't cancelled along with the call
+	notCancelled := mock.MatchedBy(func(ctx context.Context) bool { return ctx.Err() == nil })
+	pandora.On("Stop", notCancelled, "", "1").Return([]string{}, nil).Once()
+	mem.EXPECT().Delete(notCancelled, "1").Return(nil).Once()
+
+	_, err := recorder.Start(ctx, &pb.StartRecordRequest{VoiceChannelId: "1", Roll20GameId: "2"})
+	assert.ErrorIs(t, err, context.Canceled)
+	pandora.AssertExpectations(t)
+	mem.AssertExpectations(t)
+	r20Rec.AssertNotCalled(t, "Start", mock.Anything, mock.Anything)
+}
+
+// Pandora didn't reply in time, but may have started anyway
+func TestRecorder_StartPandoraTimeout(t *testing.T) {
+	pando := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pando, &r20Rec, &mem, RecorderOpt{})
+	mem.EXPECT().Get(mock.Anything, "1").Return(nil, nil)
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Return(nil)
+	pando.On("Start", mock.Anything, "1").Return("a", fmt.Errorf("%w, could not start recording", pandora.ErrTimeout))
+	pando.On("Stop", mock.Anything, "a", "1").Return([]string{}, nil).Once()
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil).Once()
+
+	_, err := recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: "1"})
+	assert.ErrorIs(t, err, pandora.ErrTimeout)
+	pando.AssertExpectations(t)
+	mem.AssertExpectations(t)
+}
+
+// Roll20 failing doesn't fail the start, unless it failed because the caller gave up
+func TestRecorder_StartCancelledDuringRoll20(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	ctx, cancel := context.WithCancel(context.Background())
+	mem.EXPECT().Get(mock.Anything, "1").Return(nil, nil)
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Return(nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil).Once()
+	pandora.On("Start", mock.Anything, "1").Return("", nil)
+	pandora.On("Stop", mock.Anything, "", "1").Return([]string{}, nil).Once()
+	r20Rec.On("Start", mock.Anything, "2").Run(func(mock.Arguments) { cancel() }).Return(context.Canceled)
+	r20Rec.On("Stop", mock.Anything, "2").Return("", nil).Once()
+
+	_, err := recorder.Start(ctx, &pb.StartRecordRequest{VoiceChannelId: "1", Roll20GameId: "2"})
+	var sErr *SagaError
+	assert.ErrorAs(t, err, &sErr)
+	assert.Equal(t, "roll20", sErr.Step)
+	assert.Equal(t, []string{"roll20", "pandora", "memory"}, sErr.Compensations)
+}
+
+// A stop interrupted before Pandora replied is left for a retry to complete
+func TestRecorder_StopCancelledBeforeUpload(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	ctx, cancel := context.WithCancel(context.Background())
+	var saved memory.State
+	mem.EXPECT().Get(mock.Anything, "1").Return(recordingState("1", ""), nil)
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Run(func(ctx context.Context, key string, value memory.State) {
+		saved = value
+	}).Return(nil)
+	pandora.On("Stop", mock.Anything, "", "1").Run(func(mock.Arguments) { cancel() }).Return([]string{}, context.Canceled)
+
+	_, err := recorder.Stop(ctx, &pb.StopRecordRequest{VoiceChannelId: "1"})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, memory.Stopping, saved.Phase)
+	assert.Equal(t, memory.SourceRecording, saved.Discord.Status)
+	mem.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+// Once Pandora uploaded the tracks, the stop is completed even if the caller gave up
+func TestRecorder_StopCancelledAfterUpload(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	ctx, cancel := context.WithCancel(context.Background())
+	notCancelled := mock.MatchedBy(func(ctx context.Context) bool { return ctx.Err() == nil })
+	mem.EXPECT().Get(mock.Anything, "1").Return(recordingState("1", "2"), nil)
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Return(nil)
+	mem.EXPECT().Delete(notCancelled, "1").Return(nil).Once()
+	pandora.On("Stop", mock.Anything, "", "1").Run(func(mock.Arguments) { cancel() }).Return([]string{"a"}, nil)
+	r20Rec.On("Stop", notCancelled, "2").Return("2.ogg", nil).Once()
+
+	reply, err := recorder.Stop(ctx, &pb.StopRecordRequest{VoiceChannelId: "1", Roll20GameId: "2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "2.ogg", reply.Roll20Key)
+	mem.AssertExpectations(t)
+	r20Rec.AssertExpectations(t)
+}
+
+// Calls are routed to the Pandora instance picked when starting
+func TestRecorder_RoutesToPandoraInstance(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, nil, &mem, RecorderOpt{})
+	var saved memory.State
+	mem.EXPECT().Get(mock.Anything, "1").Return(nil, nil).Once()
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Run(func(ctx context.Context, key string, value memory.State) {
+		saved = value
+	}).Return(nil)
+	pandora.On("Start", mock.Anything, "1").Return("b", nil)
+
+	_, err := recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "b", saved.PandoraInstance)
+
+	mem.EXPECT().Get(mock.Anything, "1").Return(&saved, nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil)
+	pandora.On("Stop", mock.Anything, "b", "1").Return([]string{"a"}, nil).Once()
+	reply, err := recorder.Stop(context.Background(), &pb.StopRecordRequest{VoiceChannelId: "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a"}, reply.DiscordKeys)
+	pandora.AssertExpectations(t)
+}
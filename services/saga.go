@@ -0,0 +1,74 @@
+package services
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// A compensating action, undoing a step of the saga that succeeded
+type compensation struct {
+	name string
+	fn   func() error
+}
+
+// Saga keeps track of the compensating actions of every completed step,
+// allowing to roll back the whole operation when a later step fails
+type saga struct {
+	name          string
+	compensations []compensation
+}
+
+func newSaga(name string) *saga {
+	return &saga{name: name}
+}
+
+// Register the compensating action of a step that just succeeded
+func (s *saga) onRollback(name string, fn func() error) {
+	s.compensations = append(s.compensations, compensation{name: name, fn: fn})
+}
+
+// Abort the saga because step failed with cause.
+// Every registered compensation is run in reverse order, even if one of them fails,
+// so that as much as possible is undone
+func (s *saga) abort(step string, cause error) *SagaError {
+	sErr := &SagaError{Saga: s.name, Step: step, Cause: cause}
+	for i := len(s.compensations) - 1; i >= 0; i-- {
+		c := s.compensations[i]
+		if err := c.fn(); err != nil {
+			slog.Error(fmt.Sprintf("[Saga] :: %s, compensation %s failed : %s", s.name, c.name, err.Error()))
+			sErr.FailedCompensations = append(sErr.FailedCompensations, c.name)
+			continue
+		}
+		slog.Info(fmt.Sprintf("[Saga] :: %s, compensation %s done", s.name, c.name))
+		sErr.Compensations = append(sErr.Compensations, c.name)
+	}
+	return sErr
+}
+
+// SagaError is returned when a saga step failed and the saga had to be rolled back
+type SagaError struct {
+	// Name of the saga
+	Saga string
+	// Step that failed
+	Step string
+	// Error returned by the failed step
+	Cause error
+	// Compensations that successfully ran, in execution order
+	Compensations []string
+	// Compensations that could not be completed
+	FailedCompensations []string
+}
+
+func (e *SagaError) Error() string {
+	msg := fmt.Sprintf("[Saga] :: %s failed at step %s : %s. Compensated : [%s]",
+		e.Saga, e.Step, e.Cause.Error(), strings.Join(e.Compensations, ", "))
+	if len(e.FailedCompensations) > 0 {
+		msg += fmt.Sprintf(", could not compensate : [%s]", strings.Join(e.FailedCompensations, ", "))
+	}
+	return msg
+}
+
+func (e *SagaError) Unwrap() error {
+	return e.Cause
+}
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"log/slog"
+	"record-orchestrator/pkg/memory"
+	pb "record-orchestrator/proto"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Reason of the abort of a recording whose schedule was cancelled while it was starting
+const SCHEDULE_CANCELLED_REASON = "schedule was cancelled while the recording was starting"
+
+// ErrUnknownSchedule is returned when a schedule id doesn't match any schedule
+var ErrUnknownSchedule = errors.New("[Scheduler] :: no schedule with id")
+
+// Scheduler starts recordings planned in advance, leaving it to the recorder
+// watchdog to stop them. Schedules are persisted, and armed again when the orchestrator restarts
+type Scheduler struct {
+	recorder *Recorder
+	store    memory.ScheduleStore
+	mu       sync.Mutex
+	// Pending start of each schedule
+	timers map[string]*time.Timer
+}
+
+func NewScheduler(recorder *Recorder, store memory.ScheduleStore) *Scheduler {
+	return &Scheduler{
+		recorder: recorder,
+		store:    store,
+		timers:   make(map[string]*time.Timer),
+	}
+}
+
+// Load every persisted schedule and arm it
+func (s *Scheduler) Load(ctx context.Context) error {
+	schedules, err := s.list(ctx)
+	if err != nil {
+		return err
+	}
+	for _, schedule := range schedules {
+		s.arm(schedule)
+	}
+	slog.Info(fmt.Sprintf("[Scheduler] :: Loaded %d schedules", len(schedules)))
+	return nil
+}
+
+// Schedule a new recording
+func (s *Scheduler) Schedule(ctx context.Context, payload *pb.ScheduleRecordRequest) (*pb.ScheduledRecording, error) {
+	if payload.VoiceChannelId == "" {
+		return nil, fmt.Errorf("[Scheduler] :: %w, voice channel id is required but got %+v", ErrInvalidArgument, payload)
+	}
+	if payload.MaxDurationMs <= 0 {
+		return nil, fmt.Errorf("[Scheduler] :: %w, a positive maximum duration is required but got %+v", ErrInvalidArgument, payload)
+	}
+	metadata, err := fromPbMetadata(payload.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+	startAt := time.UnixMilli(payload.StartAt)
+	if startAt.Before(time.Now()) {
+		return nil, fmt.Errorf("[Scheduler] :: %w, cannot schedule a recording in the past, got %s", ErrInvalidArgument, startAt)
+	}
+	schedule := &memory.Schedule{
+		Id:          uuid.NewString(),
+		VcId:        payload.VoiceChannelId,
+		R20Id:       payload.Roll20GameId,
+		StartAt:     startAt,
+		MaxDuration: time.Duration(payload.MaxDurationMs) * time.Millisecond,
+		Requester:   payload.Requester,
+		Status:      memory.SchedulePending,
+		Metadata:    metadata,
+		Mix:         payload.GetMix(),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// A voice channel can only be recorded once at a time
+	schedules, err := s.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range schedules {
+		if other.VcId == schedule.VcId && other.Status != memory.ScheduleFailed && overlaps(other, schedule) {
+			return nil, fmt.Errorf("[Scheduler] :: voice channel %s is already scheduled to be recorded by %s", schedule.VcId, other.Id)
+		}
+	}
+	if err = s.store.Save(ctx, schedule.Id, *schedule); err != nil {
+		return nil, err
+	}
+	s.armLocked(schedule)
+	slog.Info(fmt.Sprintf("[Scheduler] :: Scheduled recording %+v", schedule))
+	return toScheduledRecording(schedule), nil
+}
+
+// List the schedules, soonest first
+func (s *Scheduler) List(ctx context.Context, payload *pb.ListSchedulesRequest) (*pb.ListSchedulesReply, error) {
+	schedules, err := s.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reply := &pb.ListSchedulesReply{Schedules: make([]*pb.ScheduledRecording, 0, len(schedules))}
+	for _, schedule := range schedules {
+		if payload.GetVoiceChannelId() == "" || schedule.VcId == payload.GetVoiceChannelId() {
+			reply.Schedules = append(reply.Schedules, toScheduledRecording(schedule))
+		}
+	}
+	return reply, nil
+}
+
+// Cancel a schedule that didn't start yet
+func (s *Scheduler) Cancel(ctx context.Context, payload *pb.CancelScheduleRequest) (*pb.CancelScheduleReply, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	schedule, err := s.store.Get(ctx, payload.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil {
+		return nil, fmt.Errorf("%w %s", ErrUnknownSchedule, payload.GetId())
+	}
+	s.disarmLocked(schedule.Id)
+	if err = s.store.Delete(ctx, schedule.Id); err != nil {
+		return nil, err
+	}
+	slog.Info(fmt.Sprintf("[Scheduler] :: Cancelled schedule %s", schedule.Id))
+	return &pb.CancelScheduleReply{}, nil
+}
+
+// Arm the start of a pending schedule
+func (s *Scheduler) arm(schedule *memory.Schedule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.armLocked(schedule)
+}
+
+func (s *Scheduler) armLocked(schedule *memory.Schedule) {
+	s.disarmLocked(schedule.Id)
+	if schedule.Status != memory.SchedulePending {
+		return
+	}
+	id := schedule.Id
+	// If the orchestrator was down when the recording should have started,
+	// it is started late, unless it should already be over
+	if time.Now().After(schedule.StartAt.Add(schedule.MaxDuration)) {
+		s.timers[id] = time.AfterFunc(0, func() { s.fail(id, fmt.Errorf("missed, the orchestrator was down")) })
+		return
+	}
+	s.timers[id] = time.AfterFunc(time.Until(schedule.StartAt), func() { s.begin(id) })
+}
+
+func (s *Scheduler) disarmLocked(id string) {
+	if timer, ok := s.timers[id]; ok {
+		timer.Stop()
+		delete(s.timers, id)
+	}
+}
+
+// Start the recording of a schedule. The schedule is done once the recording started.
+// Starting takes a while, so the schedule may be cancelled meanwhile, in which case the recording is aborted
+func (s *Scheduler) begin(id string) {
+	ctx := context.Background()
+	s.mu.Lock()
+	schedule, err := s.store.Get(ctx, id)
+	s.mu.Unlock()
+	if err != nil || schedule == nil || schedule.Status != memory.SchedulePending {
+		return
+	}
+	slog.Info(fmt.Sprintf("[Scheduler] :: Starting scheduled recording %s", id))
+	// A late start still ends when the schedule planned it to
+	maxDuration := schedule.MaxDuration
+	if late := time.Since(schedule.StartAt); late > 0 {
+		maxDuration -= late
+	}
+	reply, err := s.recorder.Start(ctx, &pb.StartRecordRequest{
+		VoiceChannelId: schedule.VcId,
+		Roll20GameId:   schedule.R20Id,
+		Requester:      schedule.Requester,
+		MaxDurationMs:  max(maxDuration.Milliseconds(), 1),
+		Metadata:       toPbMetadata(schedule.Metadata),
+		Mix:            schedule.Mix,
+	})
+	if err != nil {
+		s.fail(id, err)
+		return
+	}
+	if !s.started(ctx, id) {
+		// Aborting may take as long as stopping Pandora, the other schedules aren't held meanwhile
+		slog.Warn(fmt.Sprintf("[Scheduler] :: Schedule %s was cancelled while its recording was starting, aborting it", id))
+		_, err = s.recorder.Abort(ctx, &pb.AbortRecordRequest{SessionId: reply.GetSessionId(), Reason: SCHEDULE_CANCELLED_REASON})
+		if err != nil {
+			slog.Error(fmt.Sprintf("[Scheduler] :: Could not abort the recording of cancelled schedule %s : %s", id, err.Error()))
+		}
+	}
+}
+
+// Remove a schedule whose recording started, returning false if it was cancelled meanwhile
+func (s *Scheduler) started(ctx context.Context, id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.timers, id)
+	schedule, err := s.store.Get(ctx, id)
+	if err == nil && schedule == nil {
+		return false
+	}
+	if err = s.store.Delete(ctx, id); err != nil {
+		slog.Error(fmt.Sprintf("[Scheduler] :: Could not delete started schedule %s : %s", id, err.Error()))
+	}
+	return true
+}
+
+// Mark a schedule as failed. It is kept so that the failure can be seen, until cancelled
+func (s *Scheduler) fail(id string, cause error) {
+	slog.Error(fmt.Sprintf("[Scheduler] :: Scheduled recording %s failed : %s", id, cause.Error()))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.timers, id)
+	ctx := context.Background()
+	schedule, err := s.store.Get(ctx, id)
+	if err != nil || schedule == nil {
+		return
+	}
+	schedule.Status = memory.ScheduleFailed
+	schedule.Error = cause.Error()
+	if err = s.store.Save(ctx, id, *schedule); err != nil {
+		slog.Error(fmt.Sprintf("[Scheduler] :: Could not save failed schedule %s : %s", id, err.Error()))
+	}
+}
+
+// Every persisted schedule, soonest first
+func (s *Scheduler) list(ctx context.Context) ([]*memory.Schedule, error) {
+	keys, err := s.store.Keys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	schedules := make([]*memory.Schedule, 0, len(keys))
+	for _, key := range keys {
+		schedule, err := s.store.Get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if schedule != nil {
+			schedules = append(schedules, schedule)
+		}
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].StartAt.Before(schedules[j].StartAt)
+	})
+	return schedules, nil
+}
+
+func overlaps(a, b *memory.Schedule) bool {
+	return a.StartAt.Before(b.StartAt.Add(b.MaxDuration)) && b.StartAt.Before(a.StartAt.Add(a.MaxDuration))
+}
+
+func toScheduledRecording(schedule *memory.Schedule) *pb.ScheduledRecording {
+	return &pb.ScheduledRecording{
+		Id:             schedule.Id,
+		VoiceChannelId: schedule.VcId,
+		Roll20GameId:   schedule.R20Id,
+		StartAt:        schedule.StartAt.UnixMilli(),
+		MaxDurationMs:  schedule.MaxDuration.Milliseconds(),
+		Requester:      schedule.Requester,
+		Status:         string(schedule.Status),
+		Error:          schedule.Error,
+		Metadata:       toPbMetadata(schedule.Metadata),
+		Mix:            schedule.Mix,
+	}
+}
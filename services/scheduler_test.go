@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"record-orchestrator/pkg/events"
+	"record-orchestrator/pkg/memory"
+	pb "record-orchestrator/proto"
+	test_utils "record-orchestrator/test-utils"
+	"testing"
+	"time"
+)
+
+// Schedule store backed by a map
+func scheduleStore(schedules map[string]memory.Schedule) *test_utils.MockScheduleStore {
+	store := test_utils.MockScheduleStore{}
+	store.EXPECT().Get(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, key string) (*memory.Schedule, error) {
+		if s, ok := schedules[key]; ok {
+			return &s, nil
+		}
+		return nil, nil
+	}).Maybe()
+	store.EXPECT().Save(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, key string, value memory.Schedule) error {
+		schedules[key] = value
+		return nil
+	}).Maybe()
+	store.EXPECT().Delete(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, key string) error {
+		delete(schedules, key)
+		return nil
+	}).Maybe()
+	store.EXPECT().Keys(mock.Anything).RunAndReturn(func(context.Context) ([]string, error) {
+		keys := make([]string, 0, len(schedules))
+		for k := range schedules {
+			keys = append(keys, k)
+		}
+		return keys, nil
+	}).Maybe()
+	return &store
+}
+
+func TestScheduler_Schedule(t *testing.T) {
+	schedules := map[string]memory.Schedule{}
+	scheduler := NewScheduler(nil, scheduleStore(schedules))
+	startAt := time.Now().Add(time.Hour)
+
+	ret, err := scheduler.Schedule(context.Background(), &pb.ScheduleRecordRequest{
+		VoiceChannelId: "1",
+		Roll20GameId:   "2",
+		StartAt:        startAt.UnixMilli(),
+		MaxDurationMs:  time.Hour.Milliseconds(),
+		Requester:      "gm",
+	})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, ret.Id)
+	assert.Equal(t, string(memory.SchedulePending), ret.Status)
+	assert.Contains(t, schedules, ret.Id)
+	defer scheduler.Cancel(context.Background(), &pb.CancelScheduleRequest{Id: ret.Id})
+
+	// Overlapping the first one on the same channel
+	_, err = scheduler.Schedule(context.Background(), &pb.ScheduleRecordRequest{
+		VoiceChannelId: "1",
+		StartAt:        startAt.Add(30 * time.Minute).UnixMilli(),
+		MaxDurationMs:  time.Hour.Milliseconds(),
+	})
+	assert.Error(t, err)
+	// Another channel is fine
+	other, err := scheduler.Schedule(context.Background(), &pb.ScheduleRecordRequest{
+		VoiceChannelId: "3",
+		StartAt:        startAt.UnixMilli(),
+		MaxDurationMs:  time.Hour.Milliseconds(),
+	})
+	assert.NoError(t, err)
+	defer scheduler.Cancel(context.Background(), &pb.CancelScheduleRequest{Id: other.Id})
+
+	list, err := scheduler.List(context.Background(), &pb.ListSchedulesRequest{VoiceChannelId: "1"})
+	assert.NoError(t, err)
+	assert.Len(t, list.Schedules, 1)
+	assert.Equal(t, ret.Id, list.Schedules[0].Id)
+}
+
+func TestScheduler_ScheduleInvalid(t *testing.T) {
+	scheduler := NewScheduler(nil, scheduleStore(map[string]memory.Schedule{}))
+	_, err := scheduler.Schedule(context.Background(), &pb.ScheduleRecordRequest{
+		VoiceChannelId: "1",
+		StartAt:        time.Now().Add(-time.Minute).UnixMilli(),
+		MaxDurationMs:  time.Hour.Milliseconds(),
+	})
+	assert.Error(t, err)
+	_, err = scheduler.Schedule(context.Background(), &pb.ScheduleRecordRequest{
+		VoiceChannelId: "1",
+		StartAt:        time.Now().Add(time.Minute).UnixMilli(),
+	})
+	assert.Error(t, err)
+}
+
+func TestScheduler_Cancel(t *testing.T) {
+	schedules := map[string]memory.Schedule{}
+	scheduler := NewScheduler(nil, scheduleStore(schedules))
+	ret, err := scheduler.Schedule(context.Background(), &pb.ScheduleRecordRequest{
+		VoiceChannelId: "1",
+		StartAt:        time.Now().Add(time.Hour).UnixMilli(),
+		MaxDurationMs:  time.Hour.Milliseconds(),
+	})
+	assert.NoError(t, err)
+
+	_, err = scheduler.Cancel(context.Background(), &pb.CancelScheduleRequest{Id: ret.Id})
+	assert.NoError(t, err)
+	assert.Empty(t, schedules)
+	assert.Empty(t, scheduler.timers)
+	_, err = scheduler.Cancel(context.Background(), &pb.CancelScheduleRequest{Id: ret.Id})
+	assert.Error(t, err)
+}
+
+func TestScheduler_Begin(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	var state *memory.State
+	mem.EXPECT().Get(mock.Anything, "1").Return(nil, nil)
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Run(func(ctx context.Context, key string, value memory.State) {
+		state = &value
+	}).Return(nil)
+	pandora.On("Start", mock.Anything, "1").Return("", nil)
+	r20Rec.On("Start", mock.Anything, "2").Return(nil)
+	schedules := map[string]memory.Schedule{
+		"s": {Id: "s", VcId: "1", R20Id: "2", StartAt: time.Now(), MaxDuration: time.Hour, Requester: "gm", Status: memory.SchedulePending},
+	}
+	scheduler := NewScheduler(recorder, scheduleStore(schedules))
+
+	scheduler.begin("s")
+	assert.Empty(t, schedules)
+	assert.Equal(t, "gm", state.Requester)
+	// Stopping the recording is left to the watchdog
+	assert.InDelta(t, time.Hour, state.MaxDuration, float64(time.Second))
+	_, armed := recorder.watchdogs.Load("1")
+	assert.True(t, armed)
+	recorder.unwatch("1")
+	pandora.AssertExpectations(t)
+	r20Rec.AssertExpectations(t)
+}
+
+// A schedule cancelled while its recording is starting must not leave it recording
+func TestScheduler_CancelWhileStarting(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	evts := test_utils.MockEmitter{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{Events: &evts})
+	var state *memory.State
+	mem.EXPECT().Keys(mock.Anything).Return([]string{"1"}, nil)
+	mem.EXPECT().Get(mock.Anything, "1").RunAndReturn(func(ctx context.Context, key string) (*memory.State, error) {
+		return state, nil
+	})
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Run(func(ctx context.Context, key string, value memory.State) {
+		state = &value
+	}).Return(nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil).Once()
+	schedules := map[string]memory.Schedule{
+		"s": {Id: "s", VcId: "1", StartAt: time.Now(), MaxDuration: time.Hour, Status: memory.SchedulePending},
+	}
+	scheduler := NewScheduler(recorder, scheduleStore(schedules))
+	pandora.On("Start", mock.Anything, "1").Run(func(args mock.Arguments) {
+		_, err := scheduler.Cancel(context.Background(), &pb.CancelScheduleRequest{Id: "s"})
+		assert.NoError(t, err)
+	}).Return("", nil)
+	// Other schedules can be handled while the recording is being aborted
+	pandora.On("Stop", mock.Anything, "", "1").Run(func(args mock.Arguments) {
+		assert.True(t, scheduler.mu.TryLock())
+		scheduler.mu.Unlock()
+	}).Return([]string{"a"}, nil).Once()
+	evts.EXPECT().Emit(mock.Anything).Return(nil)
+
+	scheduler.begin("s")
+	assert.Empty(t, schedules)
+	pandora.AssertExpectations(t)
+	mem.AssertExpectations(t)
+	evts.AssertCalled(t, "Emit", mock.MatchedBy(func(e events.Event) bool {
+		return e.Kind == events.Aborted && e.Message == SCHEDULE_CANCELLED_REASON
+	}))
+	_, armed := recorder.watchdogs.Load("1")
+	assert.False(t, armed)
+}
+
+func TestScheduler_BeginFailure(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	// Already recording this channel
+	mem.EXPECT().Get(mock.Anything, "1").Return(recordingState("1", ""), nil)
+	schedules := map[string]memory.Schedule{
+		"s": {Id: "s", VcId: "1", StartAt: time.Now(), MaxDuration: time.Hour, Status: memory.SchedulePending},
+	}
+	scheduler := NewScheduler(recorder, scheduleStore(schedules))
+
+	scheduler.begin("s")
+	assert.Equal(t, memory.ScheduleFailed, schedules["s"].Status)
+	assert.NotEmpty(t, schedules["s"].Error)
+	assert.NotContains(t, scheduler.timers, "s")
+	pandora.AssertNotCalled(t, "Start", mock.Anything, mock.Anything)
+}
+
+func TestScheduler_LoadMissed(t *testing.T) {
+	schedules := map[string]memory.Schedule{
+		"s": {Id: "s", VcId: "1", StartAt: time.Now().Add(-2 * time.Hour), MaxDuration: time.Hour, Status: memory.SchedulePending},
+	}
+	scheduler := NewScheduler(nil, scheduleStore(schedules))
+	assert.NoError(t, scheduler.Load(context.Background()))
+	assert.Eventually(t, func() bool {
+		scheduler.mu.Lock()
+		defer scheduler.mu.Unlock()
+		return schedules["s"].Status == memory.ScheduleFailed
+	}, time.Second, 10*time.Millisecond)
+	assert.Contains(t, schedules["s"].Error, "missed")
+}
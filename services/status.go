@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"record-orchestrator/pkg/memory"
+	"record-orchestrator/pkg/pandora"
+)
+
+// ToStatus turns an error of the recorder into a gRPC status error, telling callers why their call failed.
+// The message of the error is kept as is, errors with no known cause are left untouched
+func ToStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	var pErr *pandora.Error
+	var transition *memory.ErrIllegalTransition
+	code := codes.Unknown
+	switch {
+	case errors.As(err, &pErr):
+		code = pandoraCode(pErr.Code)
+	case errors.Is(err, pandora.ErrTimeout), errors.Is(err, pandora.ErrUnavailable):
+		code = codes.Unavailable
+	case errors.Is(err, pandora.ErrNoInstance):
+		code = codes.ResourceExhausted
+	case errors.Is(err, pandora.ErrSchemaMismatch):
+		code = codes.FailedPrecondition
+	case errors.Is(err, ErrUnknownSession), errors.Is(err, ErrNoSession), errors.Is(err, ErrUnknownSchedule):
+		code = codes.NotFound
+	case errors.Is(err, ErrInvalidArgument):
+		code = codes.InvalidArgument
+	case errors.As(err, &transition):
+		code = codes.FailedPrecondition
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	default:
+		return err
+	}
+	return status.Error(code, err.Error())
+}
+
+func pandoraCode(code pandora.ErrorCode) codes.Code {
+	switch code {
+	case pandora.MissingPermissions:
+		return codes.PermissionDenied
+	case pandora.AlreadyRecording, pandora.NotRecording:
+		return codes.FailedPrecondition
+	case pandora.ChannelNotFound:
+		return codes.NotFound
+	case pandora.VoiceConnectionFailed:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"record-orchestrator/pkg/memory"
+	"record-orchestrator/pkg/pandora"
+	"testing"
+)
+
+func TestToStatus(t *testing.T) {
+	refused := func(code pandora.ErrorCode) error {
+		return &SagaError{Saga: "start", Step: "pandora", Cause: fmt.Errorf("[Pandora] :: could not start recording : %w", &pandora.Error{Code: code, Message: "refused"})}
+	}
+	cases := map[string]struct {
+		err  error
+		code codes.Code
+	}{
+		"missing permissions":     {refused(pandora.MissingPermissions), codes.PermissionDenied},
+		"already recording":       {refused(pandora.AlreadyRecording), codes.FailedPrecondition},
+		"not recording":           {refused(pandora.NotRecording), codes.FailedPrecondition},
+		"channel not found":       {refused(pandora.ChannelNotFound), codes.NotFound},
+		"voice connection failed": {refused(pandora.VoiceConnectionFailed), codes.Unavailable},
+		"unknown pandora code":    {refused("somethingNew"), codes.Internal},
+		"pandora timeout":         {fmt.Errorf("%w, could not stop recording", pandora.ErrTimeout), codes.Unavailable},
+		"pandora busy":            {&SagaError{Saga: "start", Step: "pandora", Cause: pandora.ErrNoInstance}, codes.ResourceExhausted},
+		"schema mismatch":         {fmt.Errorf("[Pandora] :: could not start recording : %w", pandora.ErrSchemaMismatch), codes.FailedPrecondition},
+		"pandora down":            {&SagaError{Saga: "start", Step: "pandora", Cause: pandora.ErrUnavailable}, codes.Unavailable},
+		"unknown session":         {fmt.Errorf("%w %s", ErrUnknownSession, "1"), codes.NotFound},
+		"no session":              {fmt.Errorf("%w to abort on voice channel %s", ErrNoSession, "1"), codes.NotFound},
+		"unknown schedule":        {fmt.Errorf("%w %s", ErrUnknownSchedule, "s"), codes.NotFound},
+		"invalid argument":        {fmt.Errorf("%w, voice channel id is required", ErrInvalidArgument), codes.InvalidArgument},
+		"illegal transition":      {&memory.ErrIllegalTransition{From: memory.Stopping, To: memory.Paused}, codes.FailedPrecondition},
+		"deadline exceeded":       {fmt.Errorf("[Pandora] :: gave up : %w", context.DeadlineExceeded), codes.DeadlineExceeded},
+		"cancelled":               {fmt.Errorf("[Pandora] :: gave up : %w", context.Canceled), codes.Canceled},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			st, ok := status.FromError(ToStatus(c.err))
+			assert.True(t, ok)
+			assert.Equal(t, c.code, st.Code())
+			assert.Equal(t, c.err.Error(), st.Message())
+		})
+	}
+}
+
+func TestToStatus_Untouched(t *testing.T) {
+	assert.NoError(t, ToStatus(nil))
+	err := errors.New("[Recorder] :: something else")
+	assert.Equal(t, err, ToStatus(err))
+	st := status.Error(codes.InvalidArgument, "bad")
+	assert.Equal(t, st, ToStatus(st))
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"record-orchestrator/pkg/events"
+	"record-orchestrator/pkg/memory"
+	"time"
+)
+
+// Arm the watchdog of a session, stopping it once it reaches its maximum duration.
+// The duration is wall-clock time since the session started, pauses included
+func (r *Recorder) watch(state *memory.State) {
+	if state.MaxDuration <= 0 {
+		return
+	}
+	vcId, sessionId := state.VcId, state.Id
+	timer := time.AfterFunc(time.Until(state.StartedAt().Add(state.MaxDuration)), func() {
+		r.expire(vcId, sessionId)
+	})
+	if previous, loaded := r.watchdogs.Swap(vcId, timer); loaded {
+		previous.(*time.Timer).Stop()
+	}
+}
+
+// Disarm the watchdog of the session recording vcId, if any
+func (r *Recorder) unwatch(vcId string) {
+	if timer, loaded := r.watchdogs.LoadAndDelete(vcId); loaded {
+		timer.(*time.Timer).Stop()
+	}
+}
+
+// Stop the session recording vcId as it reached its maximum duration.
+// sessionId identifies the session the watchdog was armed for, as it may have ended since
+func (r *Recorder) expire(vcId string, sessionId string) {
+	defer r.lock(vcId)()
+	ctx := context.Background()
+	state, err := r.memory.Get(ctx, vcId)
+	if err != nil {
+		slog.Error(fmt.Sprintf("[Watchdog] :: Could not get session %s : %s", vcId, err.Error()))
+		return
+	}
+	if !isSession(state, sessionId) {
+		return
+	}
+	slog.Warn(fmt.Sprintf("[Watchdog] :: Session %s reached its maximum duration of %s, stopping it", vcId, state.MaxDuration))
+	state.AutoStopped = true
+	r.emit(newEvent(events.Warning, state, fmt.Sprintf("maximum duration of %s reached, stopping automatically", state.MaxDuration)))
+	_, mix, err := r.stop(ctx, vcId, state)
+	if err != nil {
+		slog.Error(fmt.Sprintf("[Watchdog] :: Could not stop session %s : %s", vcId, err.Error()))
+		return
+	}
+	// Submitted once the voice channel is unlocked
+	go r.submitMix(ctx, mix, nil)
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"record-orchestrator/pkg/memory"
+	pb "record-orchestrator/proto"
+	test_utils "record-orchestrator/test-utils"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRecorder_StartMaxDuration(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{MaxDuration: time.Hour})
+	var last memory.State
+	mem.EXPECT().Get(mock.Anything, mock.Anything).Return(nil, nil)
+	mem.EXPECT().Save(mock.Anything, mock.Anything, mock.Anything).Run(func(ctx context.Context, key string, value memory.State) {
+		last = value
+	}).Return(nil)
+	pandora.On("Start", mock.Anything, mock.Anything).Return("", nil)
+
+	// Server default
+	_, err := recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, last.MaxDuration)
+	_, armed := recorder.watchdogs.Load("1")
+	assert.True(t, armed)
+
+	// Overridden by the request
+	_, err = recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: "2", MaxDurationMs: time.Minute.Milliseconds()})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, last.MaxDuration)
+
+	_, err = recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: "3", MaxDurationMs: -1})
+	assert.Error(t, err)
+	recorder.unwatch("1")
+	recorder.unwatch("2")
+}
+
+func TestRecorder_StartNoMaxDuration(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	mem.EXPECT().Get(mock.Anything, "1").Return(nil, nil)
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Return(nil)
+	pandora.On("Start", mock.Anything, "1").Return("", nil)
+
+	_, err := recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: "1"})
+	assert.NoError(t, err)
+	_, armed := recorder.watchdogs.Load("1")
+	assert.False(t, armed)
+}
+
+func TestRecorder_WatchdogStopsSession(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	history := test_utils.MockHistoryStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{History: &history})
+	state := recordingState("1", "2")
+	state.MaxDuration = 20 * time.Millisecond
+	var stopped atomic.Bool
+	mem.EXPECT().Get(mock.Anything, "1").Return(state, nil)
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Return(nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil)
+	pandora.On("Stop", mock.Anything, "", "1").Return([]string{"a"}, nil)
+	r20Rec.On("Stop", mock.Anything, "2").Return("2.ogg", nil)
+	history.EXPECT().Save(mock.Anything, mock.Anything, mock.MatchedBy(func(r memory.Record) bool {
+		return r.AutoStopped
+	})).Run(func(context.Context, string, memory.Record) { stopped.Store(true) }).Return(nil)
+
+	recorder.watch(state)
+	assert.Eventually(t, stopped.Load, time.Second, 10*time.Millisecond)
+	_, armed := recorder.watchdogs.Load("1")
+	assert.False(t, armed)
+}
+
+func TestRecorder_WatchdogIgnoresAnotherSession(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	// The watched session was stopped by hand, and another one started since
+	mem.EXPECT().Get(mock.Anything, "1").Return(recordingState("1", ""), nil)
+
+	recorder.expire("1", "another")
+	pandora.AssertNotCalled(t, "Stop", mock.Anything, mock.Anything, mock.Anything)
+	mem.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Pauses count in the duration of a session
+func TestRecorder_WatchdogStopsResumedSession(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{MaxDuration: 300 * time.Millisecond})
+	var mu sync.Mutex
+	var state *memory.State
+	mem.EXPECT().Get(mock.Anything, "1").RunAndReturn(func(context.Context, string) (*memory.State, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return state, nil
+	})
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Run(func(ctx context.Context, key string, value memory.State) {
+		mu.Lock()
+		defer mu.Unlock()
+		state = &value
+	}).Return(nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil)
+	var stopped atomic.Bool
+	pandora.On("Start", mock.Anything, "1").Return("", nil)
+	pandora.On("Pause", mock.Anything, "", "1").Return(nil)
+	pandora.On("Resume", mock.Anything, "", "1").Return(nil)
+	pandora.On("Stop", mock.Anything, "", "1").Run(func(mock.Arguments) { stopped.Store(true) }).Return([]string{"a"}, nil).Once()
+
+	_, err := recorder.Start(context.Background(), &pb.StartRecordRequest{VoiceChannelId: "1"})
+	assert.NoError(t, err)
+	_, err = recorder.Pause(context.Background(), &pb.PauseRecordRequest{VoiceChannelId: "1"})
+	assert.NoError(t, err)
+	_, err = recorder.Resume(context.Background(), &pb.ResumeRecordRequest{VoiceChannelId: "1"})
+	assert.NoError(t, err)
+	assert.Eventually(t, stopped.Load, time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		_, armed := recorder.watchdogs.Load("1")
+		return !armed
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestRecorder_StopDisarmsWatchdog(t *testing.T) {
+	pandora := test_utils.MockDiscordRecorder{}
+	r20Rec := test_utils.MockR20Recorder{}
+	mem := test_utils.MockStateStore{}
+	recorder := NewRecorder(&pandora, &r20Rec, &mem, RecorderOpt{})
+	state := recordingState("1", "")
+	state.MaxDuration = time.Hour
+	mem.EXPECT().Get(mock.Anything, "1").Return(state, nil)
+	mem.EXPECT().Save(mock.Anything, "1", mock.Anything).Return(nil)
+	mem.EXPECT().Delete(mock.Anything, "1").Return(nil)
+	pandora.On("Stop", mock.Anything, "", "1").Return([]string{"a"}, nil)
+
+	recorder.watch(state)
+	_, err := recorder.Stop(context.Background(), &pb.StopRecordRequest{VoiceChannelId: "1"})
+	assert.NoError(t, err)
+	_, armed := recorder.watchdogs.Load("1")
+	assert.False(t, armed)
+}
@@ -2,7 +2,13 @@
 
 package test_utils
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	pandora "record-orchestrator/pkg/pandora"
+)
 
 // MockDiscordRecorder is an autogenerated mock type for the DiscordRecorder type
 type MockDiscordRecorder struct {
@@ -17,13 +23,108 @@ func (_m *MockDiscordRecorder) EXPECT() *MockDiscordRecorder_Expecter {
 	return &MockDiscordRecorder_Expecter{mock: &_m.Mock}
 }
 
-// Start provides a mock function with given fields: vcId
-func (_m *MockDiscordRecorder) Start(vcId string) error {
-	ret := _m.Called(vcId)
+// IsRecording provides a mock function with given fields: ctx, instanceId, vcId
+func (_m *MockDiscordRecorder) IsRecording(ctx context.Context, instanceId string, vcId string) (bool, error) {
+	ret := _m.Called(ctx, instanceId, vcId)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, instanceId, vcId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, instanceId, vcId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, instanceId, vcId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDiscordRecorder_IsRecording_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsRecording'
+type MockDiscordRecorder_IsRecording_Call struct {
+	*mock.Call
+}
+
+// IsRecording is a helper method to define mock.On call
+//   - ctx context.Context
+//   - instanceId string
+//   - vcId string
+func (_e *MockDiscordRecorder_Expecter) IsRecording(ctx interface{}, instanceId interface{}, vcId interface{}) *MockDiscordRecorder_IsRecording_Call {
+	return &MockDiscordRecorder_IsRecording_Call{Call: _e.mock.On("IsRecording", ctx, instanceId, vcId)}
+}
+
+func (_c *MockDiscordRecorder_IsRecording_Call) Run(run func(ctx context.Context, instanceId string, vcId string)) *MockDiscordRecorder_IsRecording_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockDiscordRecorder_IsRecording_Call) Return(_a0 bool, _a1 error) *MockDiscordRecorder_IsRecording_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDiscordRecorder_IsRecording_Call) RunAndReturn(run func(context.Context, string, string) (bool, error)) *MockDiscordRecorder_IsRecording_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Liveness provides a mock function with given fields:
+func (_m *MockDiscordRecorder) Liveness() pandora.Liveness {
+	ret := _m.Called()
+
+	var r0 pandora.Liveness
+	if rf, ok := ret.Get(0).(func() pandora.Liveness); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(pandora.Liveness)
+	}
+
+	return r0
+}
+
+// MockDiscordRecorder_Liveness_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Liveness'
+type MockDiscordRecorder_Liveness_Call struct {
+	*mock.Call
+}
+
+// Liveness is a helper method to define mock.On call
+func (_e *MockDiscordRecorder_Expecter) Liveness() *MockDiscordRecorder_Liveness_Call {
+	return &MockDiscordRecorder_Liveness_Call{Call: _e.mock.On("Liveness")}
+}
+
+func (_c *MockDiscordRecorder_Liveness_Call) Run(run func()) *MockDiscordRecorder_Liveness_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockDiscordRecorder_Liveness_Call) Return(_a0 pandora.Liveness) *MockDiscordRecorder_Liveness_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDiscordRecorder_Liveness_Call) RunAndReturn(run func() pandora.Liveness) *MockDiscordRecorder_Liveness_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Pause provides a mock function with given fields: ctx, instanceId, vcId
+func (_m *MockDiscordRecorder) Pause(ctx context.Context, instanceId string, vcId string) error {
+	ret := _m.Called(ctx, instanceId, vcId)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(vcId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, instanceId, vcId)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -31,53 +132,152 @@ func (_m *MockDiscordRecorder) Start(vcId string) error {
 	return r0
 }
 
+// MockDiscordRecorder_Pause_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Pause'
+type MockDiscordRecorder_Pause_Call struct {
+	*mock.Call
+}
+
+// Pause is a helper method to define mock.On call
+//   - ctx context.Context
+//   - instanceId string
+//   - vcId string
+func (_e *MockDiscordRecorder_Expecter) Pause(ctx interface{}, instanceId interface{}, vcId interface{}) *MockDiscordRecorder_Pause_Call {
+	return &MockDiscordRecorder_Pause_Call{Call: _e.mock.On("Pause", ctx, instanceId, vcId)}
+}
+
+func (_c *MockDiscordRecorder_Pause_Call) Run(run func(ctx context.Context, instanceId string, vcId string)) *MockDiscordRecorder_Pause_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockDiscordRecorder_Pause_Call) Return(_a0 error) *MockDiscordRecorder_Pause_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDiscordRecorder_Pause_Call) RunAndReturn(run func(context.Context, string, string) error) *MockDiscordRecorder_Pause_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Resume provides a mock function with given fields: ctx, instanceId, vcId
+func (_m *MockDiscordRecorder) Resume(ctx context.Context, instanceId string, vcId string) error {
+	ret := _m.Called(ctx, instanceId, vcId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, instanceId, vcId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDiscordRecorder_Resume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resume'
+type MockDiscordRecorder_Resume_Call struct {
+	*mock.Call
+}
+
+// Resume is a helper method to define mock.On call
+//   - ctx context.Context
+//   - instanceId string
+//   - vcId string
+func (_e *MockDiscordRecorder_Expecter) Resume(ctx interface{}, instanceId interface{}, vcId interface{}) *MockDiscordRecorder_Resume_Call {
+	return &MockDiscordRecorder_Resume_Call{Call: _e.mock.On("Resume", ctx, instanceId, vcId)}
+}
+
+func (_c *MockDiscordRecorder_Resume_Call) Run(run func(ctx context.Context, instanceId string, vcId string)) *MockDiscordRecorder_Resume_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockDiscordRecorder_Resume_Call) Return(_a0 error) *MockDiscordRecorder_Resume_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDiscordRecorder_Resume_Call) RunAndReturn(run func(context.Context, string, string) error) *MockDiscordRecorder_Resume_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Start provides a mock function with given fields: ctx, vcId
+func (_m *MockDiscordRecorder) Start(ctx context.Context, vcId string) (string, error) {
+	ret := _m.Called(ctx, vcId)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, vcId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, vcId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, vcId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // MockDiscordRecorder_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
 type MockDiscordRecorder_Start_Call struct {
 	*mock.Call
 }
 
 // Start is a helper method to define mock.On call
+//   - ctx context.Context
 //   - vcId string
-func (_e *MockDiscordRecorder_Expecter) Start(vcId interface{}) *MockDiscordRecorder_Start_Call {
-	return &MockDiscordRecorder_Start_Call{Call: _e.mock.On("Start", vcId)}
+func (_e *MockDiscordRecorder_Expecter) Start(ctx interface{}, vcId interface{}) *MockDiscordRecorder_Start_Call {
+	return &MockDiscordRecorder_Start_Call{Call: _e.mock.On("Start", ctx, vcId)}
 }
 
-func (_c *MockDiscordRecorder_Start_Call) Run(run func(vcId string)) *MockDiscordRecorder_Start_Call {
+func (_c *MockDiscordRecorder_Start_Call) Run(run func(ctx context.Context, vcId string)) *MockDiscordRecorder_Start_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockDiscordRecorder_Start_Call) Return(_a0 error) *MockDiscordRecorder_Start_Call {
-	_c.Call.Return(_a0)
+func (_c *MockDiscordRecorder_Start_Call) Return(_a0 string, _a1 error) *MockDiscordRecorder_Start_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockDiscordRecorder_Start_Call) RunAndReturn(run func(string) error) *MockDiscordRecorder_Start_Call {
+func (_c *MockDiscordRecorder_Start_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockDiscordRecorder_Start_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Stop provides a mock function with given fields: vcId
-func (_m *MockDiscordRecorder) Stop(vcId string) ([]string, error) {
-	ret := _m.Called(vcId)
+// Stop provides a mock function with given fields: ctx, instanceId, vcId
+func (_m *MockDiscordRecorder) Stop(ctx context.Context, instanceId string, vcId string) ([]string, error) {
+	ret := _m.Called(ctx, instanceId, vcId)
 
 	var r0 []string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]string, error)); ok {
-		return rf(vcId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]string, error)); ok {
+		return rf(ctx, instanceId, vcId)
 	}
-	if rf, ok := ret.Get(0).(func(string) []string); ok {
-		r0 = rf(vcId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = rf(ctx, instanceId, vcId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]string)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(vcId)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, instanceId, vcId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -91,14 +291,16 @@ type MockDiscordRecorder_Stop_Call struct {
 }
 
 // Stop is a helper method to define mock.On call
+//   - ctx context.Context
+//   - instanceId string
 //   - vcId string
-func (_e *MockDiscordRecorder_Expecter) Stop(vcId interface{}) *MockDiscordRecorder_Stop_Call {
-	return &MockDiscordRecorder_Stop_Call{Call: _e.mock.On("Stop", vcId)}
+func (_e *MockDiscordRecorder_Expecter) Stop(ctx interface{}, instanceId interface{}, vcId interface{}) *MockDiscordRecorder_Stop_Call {
+	return &MockDiscordRecorder_Stop_Call{Call: _e.mock.On("Stop", ctx, instanceId, vcId)}
 }
 
-func (_c *MockDiscordRecorder_Stop_Call) Run(run func(vcId string)) *MockDiscordRecorder_Stop_Call {
+func (_c *MockDiscordRecorder_Stop_Call) Run(run func(ctx context.Context, instanceId string, vcId string)) *MockDiscordRecorder_Stop_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -108,7 +310,7 @@ func (_c *MockDiscordRecorder_Stop_Call) Return(_a0 []string, _a1 error) *MockDi
 	return _c
 }
 
-func (_c *MockDiscordRecorder_Stop_Call) RunAndReturn(run func(string) ([]string, error)) *MockDiscordRecorder_Stop_Call {
+func (_c *MockDiscordRecorder_Stop_Call) RunAndReturn(run func(context.Context, string, string) ([]string, error)) *MockDiscordRecorder_Stop_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2,7 +2,11 @@
 
 package test_utils
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // MockR20Recorder is an autogenerated mock type for the R20Recorder type
 type MockR20Recorder struct {
@@ -17,13 +21,152 @@ func (_m *MockR20Recorder) EXPECT() *MockR20Recorder_Expecter {
 	return &MockR20Recorder_Expecter{mock: &_m.Mock}
 }
 
-// Start provides a mock function with given fields: r20Id
-func (_m *MockR20Recorder) Start(r20Id string) error {
-	ret := _m.Called(r20Id)
+// IsRecording provides a mock function with given fields: ctx, r20Id
+func (_m *MockR20Recorder) IsRecording(ctx context.Context, r20Id string) (bool, error) {
+	ret := _m.Called(ctx, r20Id)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, r20Id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, r20Id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, r20Id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockR20Recorder_IsRecording_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsRecording'
+type MockR20Recorder_IsRecording_Call struct {
+	*mock.Call
+}
+
+// IsRecording is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r20Id string
+func (_e *MockR20Recorder_Expecter) IsRecording(ctx interface{}, r20Id interface{}) *MockR20Recorder_IsRecording_Call {
+	return &MockR20Recorder_IsRecording_Call{Call: _e.mock.On("IsRecording", ctx, r20Id)}
+}
+
+func (_c *MockR20Recorder_IsRecording_Call) Run(run func(ctx context.Context, r20Id string)) *MockR20Recorder_IsRecording_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockR20Recorder_IsRecording_Call) Return(_a0 bool, _a1 error) *MockR20Recorder_IsRecording_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockR20Recorder_IsRecording_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockR20Recorder_IsRecording_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Pause provides a mock function with given fields: ctx, r20Id
+func (_m *MockR20Recorder) Pause(ctx context.Context, r20Id string) error {
+	ret := _m.Called(ctx, r20Id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, r20Id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockR20Recorder_Pause_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Pause'
+type MockR20Recorder_Pause_Call struct {
+	*mock.Call
+}
+
+// Pause is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r20Id string
+func (_e *MockR20Recorder_Expecter) Pause(ctx interface{}, r20Id interface{}) *MockR20Recorder_Pause_Call {
+	return &MockR20Recorder_Pause_Call{Call: _e.mock.On("Pause", ctx, r20Id)}
+}
+
+func (_c *MockR20Recorder_Pause_Call) Run(run func(ctx context.Context, r20Id string)) *MockR20Recorder_Pause_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockR20Recorder_Pause_Call) Return(_a0 error) *MockR20Recorder_Pause_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockR20Recorder_Pause_Call) RunAndReturn(run func(context.Context, string) error) *MockR20Recorder_Pause_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Resume provides a mock function with given fields: ctx, r20Id
+func (_m *MockR20Recorder) Resume(ctx context.Context, r20Id string) error {
+	ret := _m.Called(ctx, r20Id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, r20Id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockR20Recorder_Resume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resume'
+type MockR20Recorder_Resume_Call struct {
+	*mock.Call
+}
+
+// Resume is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r20Id string
+func (_e *MockR20Recorder_Expecter) Resume(ctx interface{}, r20Id interface{}) *MockR20Recorder_Resume_Call {
+	return &MockR20Recorder_Resume_Call{Call: _e.mock.On("Resume", ctx, r20Id)}
+}
+
+func (_c *MockR20Recorder_Resume_Call) Run(run func(ctx context.Context, r20Id string)) *MockR20Recorder_Resume_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockR20Recorder_Resume_Call) Return(_a0 error) *MockR20Recorder_Resume_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockR20Recorder_Resume_Call) RunAndReturn(run func(context.Context, string) error) *MockR20Recorder_Resume_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Start provides a mock function with given fields: ctx, r20Id
+func (_m *MockR20Recorder) Start(ctx context.Context, r20Id string) error {
+	ret := _m.Called(ctx, r20Id)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(r20Id)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, r20Id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -37,14 +180,15 @@ type MockR20Recorder_Start_Call struct {
 }
 
 // Start is a helper method to define mock.On call
+//   - ctx context.Context
 //   - r20Id string
-func (_e *MockR20Recorder_Expecter) Start(r20Id interface{}) *MockR20Recorder_Start_Call {
-	return &MockR20Recorder_Start_Call{Call: _e.mock.On("Start", r20Id)}
+func (_e *MockR20Recorder_Expecter) Start(ctx interface{}, r20Id interface{}) *MockR20Recorder_Start_Call {
+	return &MockR20Recorder_Start_Call{Call: _e.mock.On("Start", ctx, r20Id)}
 }
 
-func (_c *MockR20Recorder_Start_Call) Run(run func(r20Id string)) *MockR20Recorder_Start_Call {
+func (_c *MockR20Recorder_Start_Call) Run(run func(ctx context.Context, r20Id string)) *MockR20Recorder_Start_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -54,28 +198,28 @@ func (_c *MockR20Recorder_Start_Call) Return(_a0 error) *MockR20Recorder_Start_C
 	return _c
 }
 
-func (_c *MockR20Recorder_Start_Call) RunAndReturn(run func(string) error) *MockR20Recorder_Start_Call {
+func (_c *MockR20Recorder_Start_Call) RunAndReturn(run func(context.Context, string) error) *MockR20Recorder_Start_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Stop provides a mock function with given fields: r20Id
-func (_m *MockR20Recorder) Stop(r20Id string) (string, error) {
-	ret := _m.Called(r20Id)
+// Stop provides a mock function with given fields: ctx, r20Id
+func (_m *MockR20Recorder) Stop(ctx context.Context, r20Id string) (string, error) {
+	ret := _m.Called(ctx, r20Id)
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(r20Id)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, r20Id)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(r20Id)
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, r20Id)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(r20Id)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, r20Id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -89,14 +233,15 @@ type MockR20Recorder_Stop_Call struct {
 }
 
 // Stop is a helper method to define mock.On call
+//   - ctx context.Context
 //   - r20Id string
-func (_e *MockR20Recorder_Expecter) Stop(r20Id interface{}) *MockR20Recorder_Stop_Call {
-	return &MockR20Recorder_Stop_Call{Call: _e.mock.On("Stop", r20Id)}
+func (_e *MockR20Recorder_Expecter) Stop(ctx interface{}, r20Id interface{}) *MockR20Recorder_Stop_Call {
+	return &MockR20Recorder_Stop_Call{Call: _e.mock.On("Stop", ctx, r20Id)}
 }
 
-func (_c *MockR20Recorder_Stop_Call) Run(run func(r20Id string)) *MockR20Recorder_Stop_Call {
+func (_c *MockR20Recorder_Stop_Call) Run(run func(ctx context.Context, r20Id string)) *MockR20Recorder_Stop_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -106,7 +251,7 @@ func (_c *MockR20Recorder_Stop_Call) Return(_a0 string, _a1 error) *MockR20Recor
 	return _c
 }
 
-func (_c *MockR20Recorder_Stop_Call) RunAndReturn(run func(string) (string, error)) *MockR20Recorder_Stop_Call {
+func (_c *MockR20Recorder_Stop_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockR20Recorder_Stop_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3,6 +3,8 @@
 package test_utils
 
 import (
+	context "context"
+
 	memory "record-orchestrator/pkg/memory"
 
 	mock "github.com/stretchr/testify/mock"
@@ -21,13 +23,13 @@ func (_m *MockStateStore) EXPECT() *MockStateStore_Expecter {
 	return &MockStateStore_Expecter{mock: &_m.Mock}
 }
 
-// Delete provides a mock function with given fields: key
-func (_m *MockStateStore) Delete(key string) error {
-	ret := _m.Called(key)
+// Delete provides a mock function with given fields: ctx, key
+func (_m *MockStateStore) Delete(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(key)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -41,14 +43,15 @@ type MockStateStore_Delete_Call struct {
 }
 
 // Delete is a helper method to define mock.On call
+//   - ctx context.Context
 //   - key string
-func (_e *MockStateStore_Expecter) Delete(key interface{}) *MockStateStore_Delete_Call {
-	return &MockStateStore_Delete_Call{Call: _e.mock.On("Delete", key)}
+func (_e *MockStateStore_Expecter) Delete(ctx interface{}, key interface{}) *MockStateStore_Delete_Call {
+	return &MockStateStore_Delete_Call{Call: _e.mock.On("Delete", ctx, key)}
 }
 
-func (_c *MockStateStore_Delete_Call) Run(run func(key string)) *MockStateStore_Delete_Call {
+func (_c *MockStateStore_Delete_Call) Run(run func(ctx context.Context, key string)) *MockStateStore_Delete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -58,30 +61,30 @@ func (_c *MockStateStore_Delete_Call) Return(_a0 error) *MockStateStore_Delete_C
 	return _c
 }
 
-func (_c *MockStateStore_Delete_Call) RunAndReturn(run func(string) error) *MockStateStore_Delete_Call {
+func (_c *MockStateStore_Delete_Call) RunAndReturn(run func(context.Context, string) error) *MockStateStore_Delete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Get provides a mock function with given fields: key
-func (_m *MockStateStore) Get(key string) (*memory.State, error) {
-	ret := _m.Called(key)
+// Get provides a mock function with given fields: ctx, key
+func (_m *MockStateStore) Get(ctx context.Context, key string) (*memory.State, error) {
+	ret := _m.Called(ctx, key)
 
 	var r0 *memory.State
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*memory.State, error)); ok {
-		return rf(key)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*memory.State, error)); ok {
+		return rf(ctx, key)
 	}
-	if rf, ok := ret.Get(0).(func(string) *memory.State); ok {
-		r0 = rf(key)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *memory.State); ok {
+		r0 = rf(ctx, key)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*memory.State)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(key)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -95,14 +98,15 @@ type MockStateStore_Get_Call struct {
 }
 
 // Get is a helper method to define mock.On call
+//   - ctx context.Context
 //   - key string
-func (_e *MockStateStore_Expecter) Get(key interface{}) *MockStateStore_Get_Call {
-	return &MockStateStore_Get_Call{Call: _e.mock.On("Get", key)}
+func (_e *MockStateStore_Expecter) Get(ctx interface{}, key interface{}) *MockStateStore_Get_Call {
+	return &MockStateStore_Get_Call{Call: _e.mock.On("Get", ctx, key)}
 }
 
-func (_c *MockStateStore_Get_Call) Run(run func(key string)) *MockStateStore_Get_Call {
+func (_c *MockStateStore_Get_Call) Run(run func(ctx context.Context, key string)) *MockStateStore_Get_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -112,18 +116,72 @@ func (_c *MockStateStore_Get_Call) Return(_a0 *memory.State, _a1 error) *MockSta
 	return _c
 }
 
-func (_c *MockStateStore_Get_Call) RunAndReturn(run func(string) (*memory.State, error)) *MockStateStore_Get_Call {
+func (_c *MockStateStore_Get_Call) RunAndReturn(run func(context.Context, string) (*memory.State, error)) *MockStateStore_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Keys provides a mock function with given fields: ctx
+func (_m *MockStateStore) Keys(ctx context.Context) ([]string, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStateStore_Keys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Keys'
+type MockStateStore_Keys_Call struct {
+	*mock.Call
+}
+
+// Keys is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStateStore_Expecter) Keys(ctx interface{}) *MockStateStore_Keys_Call {
+	return &MockStateStore_Keys_Call{Call: _e.mock.On("Keys", ctx)}
+}
+
+func (_c *MockStateStore_Keys_Call) Run(run func(ctx context.Context)) *MockStateStore_Keys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockStateStore_Keys_Call) Return(_a0 []string, _a1 error) *MockStateStore_Keys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStateStore_Keys_Call) RunAndReturn(run func(context.Context) ([]string, error)) *MockStateStore_Keys_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Save provides a mock function with given fields: key, value
-func (_m *MockStateStore) Save(key string, value memory.State) error {
-	ret := _m.Called(key, value)
+// Save provides a mock function with given fields: ctx, key, value
+func (_m *MockStateStore) Save(ctx context.Context, key string, value memory.State) error {
+	ret := _m.Called(ctx, key, value)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, memory.State) error); ok {
-		r0 = rf(key, value)
+	if rf, ok := ret.Get(0).(func(context.Context, string, memory.State) error); ok {
+		r0 = rf(ctx, key, value)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -137,15 +195,16 @@ type MockStateStore_Save_Call struct {
 }
 
 // Save is a helper method to define mock.On call
+//   - ctx context.Context
 //   - key string
 //   - value memory.State
-func (_e *MockStateStore_Expecter) Save(key interface{}, value interface{}) *MockStateStore_Save_Call {
-	return &MockStateStore_Save_Call{Call: _e.mock.On("Save", key, value)}
+func (_e *MockStateStore_Expecter) Save(ctx interface{}, key interface{}, value interface{}) *MockStateStore_Save_Call {
+	return &MockStateStore_Save_Call{Call: _e.mock.On("Save", ctx, key, value)}
 }
 
-func (_c *MockStateStore_Save_Call) Run(run func(key string, value memory.State)) *MockStateStore_Save_Call {
+func (_c *MockStateStore_Save_Call) Run(run func(ctx context.Context, key string, value memory.State)) *MockStateStore_Save_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(memory.State))
+		run(args[0].(context.Context), args[1].(string), args[2].(memory.State))
 	})
 	return _c
 }
@@ -155,7 +214,7 @@ func (_c *MockStateStore_Save_Call) Return(_a0 error) *MockStateStore_Save_Call
 	return _c
 }
 
-func (_c *MockStateStore_Save_Call) RunAndReturn(run func(string, memory.State) error) *MockStateStore_Save_Call {
+func (_c *MockStateStore_Save_Call) RunAndReturn(run func(context.Context, string, memory.State) error) *MockStateStore_Save_Call {
 	_c.Call.Return(run)
 	return _c
 }